@@ -0,0 +1,117 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+func (a *Analyzer) analyzeABIDiff(account eos.AccountName, newABI *eos.ABI) {
+	currentABI, err := a.ABIFetcher(account)
+	if err != nil {
+		a.Pf("Couldn't fetch the ABI currently deployed on %s: %s\n", account, err)
+		return
+	}
+	if currentABI == nil {
+		currentABI = &eos.ABI{}
+	}
+
+	diffs := diffABI(currentABI, newABI)
+	if len(diffs) == 0 {
+		a.Pln("ABI is identical to the one currently deployed")
+		return
+	}
+
+	a.Pf("Changes versus the ABI currently deployed: %d\n", len(diffs))
+	defer a.nest()()
+	for _, diff := range diffs {
+		a.Pf("%s\n", diff)
+	}
+}
+
+// diffABI lists the structs, actions and tables added, removed or
+// changed between `oldABI` and `newABI`.
+func diffABI(oldABI, newABI *eos.ABI) (out []string) {
+	out = append(out, diffDefs("struct", abiStructs(oldABI), abiStructs(newABI))...)
+	out = append(out, diffDefs("action", abiActions(oldABI), abiActions(newABI))...)
+	out = append(out, diffDefs("table", abiTables(oldABI), abiTables(newABI))...)
+	return
+}
+
+// abiInventory returns a one-line index of the actions and tables
+// declared by `abi`, in declaration order.
+func abiInventory(abi *eos.ABI) string {
+	var actions, tables []string
+	for _, def := range abi.Actions {
+		actions = append(actions, string(def.Name))
+	}
+	for _, def := range abi.Tables {
+		tables = append(tables, string(def.Name))
+	}
+
+	return fmt.Sprintf("ABI declares %d actions: %s and %d tables: %s", len(actions), strings.Join(actions, ", "), len(tables), strings.Join(tables, ", "))
+}
+
+// diffDefs compares definitions keyed by name, each serialized to
+// JSON so that nil and empty lists compare equal.
+func diffDefs(kind string, oldDefs, newDefs map[string]string) (out []string) {
+	var names []string
+	for name := range oldDefs {
+		names = append(names, name)
+	}
+	for name := range newDefs {
+		if _, found := oldDefs[name]; !found {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		oldDef, inOld := oldDefs[name]
+		newDef, inNew := newDefs[name]
+		switch {
+		case !inOld:
+			out = append(out, fmt.Sprintf("added %s: %s", kind, name))
+		case !inNew:
+			out = append(out, fmt.Sprintf("removed %s: %s", kind, name))
+		case oldDef != newDef:
+			out = append(out, fmt.Sprintf("changed %s: %s", kind, name))
+		}
+	}
+	return
+}
+
+func abiStructs(abi *eos.ABI) map[string]string {
+	out := map[string]string{}
+	for _, def := range abi.Structs {
+		out[def.Name] = jsonString(def)
+	}
+	return out
+}
+
+func abiActions(abi *eos.ABI) map[string]string {
+	out := map[string]string{}
+	for _, def := range abi.Actions {
+		out[string(def.Name)] = jsonString(def)
+	}
+	return out
+}
+
+func abiTables(abi *eos.ABI) map[string]string {
+	out := map[string]string{}
+	for _, def := range abi.Tables {
+		out[string(def.Name)] = jsonString(def)
+	}
+	return out
+}
+
+func jsonString(v interface{}) string {
+	cnt, err := json.Marshal(v)
+	if err != nil {
+		return err.Error()
+	}
+	return string(cnt)
+}
@@ -0,0 +1,9 @@
+package analysis
+
+import "testing"
+
+func TestAnalyzeSetABIInventory(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(testSetABI(t, "eosio.token", testTokenABI())))
+	assertContains(t, out, "ABI declares 3 actions: transfer, issue, create and 2 tables: accounts, stat\n")
+	assertNotContains(t, out, "JSON representation of the ABI")
+}
@@ -0,0 +1,371 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+)
+
+// RegisterABI makes the actions of `account` decodable through `abi`,
+// for contracts whose types `eos-go` doesn't know about. It replaces
+// any ABI previously registered for `account`.
+func (a *Analyzer) RegisterABI(account eos.AccountName, abi *eos.ABI) {
+	if a.abis == nil {
+		a.abis = map[eos.AccountName]*eos.ABI{}
+	}
+	a.abis[account] = abi
+}
+
+// TryDecode decodes the hex-encoded `hexData` as the arguments of the
+// action `account::name`, using the types registered with `eos-go`
+// or, failing that, the ABI registered with `RegisterABI()`.
+func (a *Analyzer) TryDecode(account eos.AccountName, name eos.ActionName, hexData string) (json.RawMessage, error) {
+	data, err := hex.DecodeString(strings.TrimSpace(hexData))
+	if err != nil {
+		return nil, fmt.Errorf("decoding hex, %s", err)
+	}
+
+	act := &eos.Action{Account: account, Name: name, ActionData: eos.ActionData{HexData: data}}
+	if err := unpackActionData(act); err != nil {
+		return nil, err
+	}
+
+	decoded := actionData(act)
+	if decoded == nil {
+		abi := a.abis[account]
+		if abi == nil {
+			return nil, fmt.Errorf("no ABI known for %s::%s", account, name)
+		}
+		if decoded, err = decodeABIAction(abi, name, data); err != nil {
+			return nil, fmt.Errorf("decoding %s::%s with registered ABI, %s", account, name, err)
+		}
+	}
+
+	return json.Marshal(decoded)
+}
+
+// analyzeABIAction decodes `act` with the ABI registered for its
+// account, and prints the result as JSON. It returns false when no
+// ABI was registered for it, and an error when its data doesn't
+// match the ABI.
+func (a *Analyzer) analyzeABIAction(act *eos.Action) (ok bool, err error) {
+	abi := a.abis[act.Account]
+	if abi == nil {
+		return false, nil
+	}
+
+	decoded, err := decodeABIAction(abi, act.Name, act.HexData)
+	if err != nil {
+		return true, err
+	}
+
+	if obj, ok := decoded.(abiObject); ok && a.redactsMemos() {
+		decoded = a.redactABIMemos(obj)
+	}
+
+	cnt, err := json.MarshalIndent(decoded, "", "  ")
+	if err != nil {
+		return true, fmt.Errorf("serializing into JSON, %s", err)
+	}
+	a.Pf("Data (decoded with registered ABI): %s%s\n", string(cnt), a.rawSuffix(act))
+
+	return true, nil
+}
+
+// maxABIDepth bounds the nesting of ABI types, which could otherwise
+// be made to recurse forever.
+const maxABIDepth = 32
+
+// decodeABIAction decodes `data` as the arguments of the action
+// `name`, as declared in `abi`.
+func decodeABIAction(abi *eos.ABI, name eos.ActionName, data []byte) (interface{}, error) {
+	for _, def := range abi.Actions {
+		if def.Name != name {
+			continue
+		}
+
+		dec := &abiDecoder{abi: abi, data: data}
+		out, err := dec.decode(def.Type, 0)
+		if err != nil {
+			return nil, err
+		}
+		if dec.pos != len(data) {
+			return nil, fmt.Errorf("%d trailing bytes", len(data)-dec.pos)
+		}
+		return out, nil
+	}
+
+	return nil, fmt.Errorf("action %q not declared in ABI", name)
+}
+
+type abiDecoder struct {
+	abi  *eos.ABI
+	data []byte
+	pos  int
+}
+
+func (d *abiDecoder) decode(typeName string, depth int) (interface{}, error) {
+	if depth > maxABIDepth {
+		return nil, fmt.Errorf("types nested deeper than %d levels", maxABIDepth)
+	}
+	typeName = d.resolve(typeName)
+
+	if strings.HasSuffix(typeName, "[]") {
+		count, err := d.readUvarint()
+		if err != nil {
+			return nil, err
+		}
+		if count > uint64(len(d.data)-d.pos) {
+			return nil, fmt.Errorf("array of %d elements longer than the data", count)
+		}
+		out := []interface{}{}
+		for i := uint64(0); i < count; i++ {
+			elem, err := d.decode(strings.TrimSuffix(typeName, "[]"), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, elem)
+		}
+		return out, nil
+	}
+
+	if strings.HasSuffix(typeName, "?") {
+		present, err := d.read(1)
+		if err != nil {
+			return nil, err
+		}
+		if present[0] == 0 {
+			return nil, nil
+		}
+		return d.decode(strings.TrimSuffix(typeName, "?"), depth+1)
+	}
+
+	if out, ok, err := d.decodeBuiltin(typeName); ok {
+		return out, err
+	}
+
+	for _, def := range d.abi.Structs {
+		if def.Name == typeName {
+			return d.decodeStruct(def, depth)
+		}
+	}
+
+	return nil, fmt.Errorf("unknown type %q", typeName)
+}
+
+// resolve follows the type aliases declared in the ABI.
+func (d *abiDecoder) resolve(typeName string) string {
+	for i := 0; i < maxABIDepth; i++ {
+		found := false
+		for _, alias := range d.abi.Types {
+			if alias.NewTypeName == typeName {
+				typeName = alias.Type
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+	return typeName
+}
+
+func (d *abiDecoder) decodeStruct(def eos.StructDef, depth int) (abiObject, error) {
+	var out abiObject
+	if def.Base != "" {
+		base, err := d.decode(def.Base, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		baseObj, ok := base.(abiObject)
+		if !ok {
+			return nil, fmt.Errorf("base %q of struct %q isn't a struct", def.Base, def.Name)
+		}
+		out = append(out, baseObj...)
+	}
+
+	for _, field := range def.Fields {
+		value, err := d.decode(field.Type, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("field %s.%s, %s", def.Name, field.Name, err)
+		}
+		out = append(out, abiField{Name: field.Name, Value: value})
+	}
+
+	return out, nil
+}
+
+// decodeBuiltin decodes the types built in the ABI serializer. It
+// returns false when `typeName` isn't one of them.
+func (d *abiDecoder) decodeBuiltin(typeName string) (out interface{}, ok bool, err error) {
+	size := map[string]int{
+		"bool": 1, "int8": 1, "uint8": 1, "int16": 2, "uint16": 2,
+		"int32": 4, "uint32": 4, "int64": 8, "uint64": 8,
+		"float32": 4, "float64": 8,
+		"name": 8, "account_name": 8, "permission_name": 8, "action_name": 8, "table_name": 8, "scope_name": 8,
+		"symbol": 8, "symbol_code": 8, "asset": 16,
+		"checksum160": 20, "checksum256": 32, "checksum512": 64,
+		"public_key": 34, "signature": 66,
+		"time_point": 8, "time_point_sec": 4, "block_timestamp_type": 4,
+	}
+
+	switch typeName {
+	case "string", "bytes":
+		length, err := d.readUvarint()
+		if err != nil {
+			return nil, true, err
+		}
+		if length > uint64(len(d.data)-d.pos) {
+			return nil, true, fmt.Errorf("%s of %d bytes longer than the data", typeName, length)
+		}
+		data, _ := d.read(int(length))
+		if typeName == "string" {
+			return string(data), true, nil
+		}
+		return hex.EncodeToString(data), true, nil
+
+	case "varuint32":
+		v, err := d.readUvarint()
+		return v, true, err
+
+	case "varint32":
+		v, err := d.readUvarint()
+		return int64(v>>1) ^ -int64(v&1), true, err
+	}
+
+	n, known := size[typeName]
+	if !known {
+		return nil, false, nil
+	}
+	data, err := d.read(n)
+	if err != nil {
+		return nil, true, err
+	}
+
+	le := binary.LittleEndian
+	switch typeName {
+	case "bool":
+		return data[0] != 0, true, nil
+	case "int8":
+		return int8(data[0]), true, nil
+	case "uint8":
+		return data[0], true, nil
+	case "int16":
+		return int16(le.Uint16(data)), true, nil
+	case "uint16":
+		return le.Uint16(data), true, nil
+	case "int32":
+		return int32(le.Uint32(data)), true, nil
+	case "uint32":
+		return le.Uint32(data), true, nil
+	case "int64":
+		return int64(le.Uint64(data)), true, nil
+	case "uint64":
+		return le.Uint64(data), true, nil
+	case "float32":
+		return math.Float32frombits(le.Uint32(data)), true, nil
+	case "float64":
+		return math.Float64frombits(le.Uint64(data)), true, nil
+	case "symbol":
+		return fmt.Sprintf("%d,%s", data[0], symbolCode(data[1:])), true, nil
+	case "symbol_code":
+		return symbolCode(data), true, nil
+	case "asset":
+		return eos.Asset{
+			Amount: int64(le.Uint64(data)),
+			Symbol: eos.Symbol{Precision: data[8], Symbol: symbolCode(data[9:])},
+		}.String(), true, nil
+	case "public_key":
+		return ecc.PublicKey{Curve: ecc.CurveID(data[0]), Content: data[1:]}.String(), true, nil
+	case "signature":
+		return ecc.Signature{Curve: ecc.CurveID(data[0]), Content: data[1:]}.String(), true, nil
+	case "time_point":
+		return time.Unix(0, int64(le.Uint64(data))*int64(time.Microsecond)).UTC(), true, nil
+	case "time_point_sec":
+		return time.Unix(int64(le.Uint32(data)), 0).UTC(), true, nil
+	case "block_timestamp_type":
+		// Half-second slots since 2000-01-01.
+		return time.Unix(946684800, 0).Add(time.Duration(le.Uint32(data)) * 500 * time.Millisecond).UTC(), true, nil
+	case "checksum160", "checksum256", "checksum512":
+		return hex.EncodeToString(data), true, nil
+	}
+
+	// Names of all flavors.
+	return eos.NameToString(le.Uint64(data)), true, nil
+}
+
+func (d *abiDecoder) read(n int) ([]byte, error) {
+	if len(d.data)-d.pos < n {
+		return nil, fmt.Errorf("expected %d bytes, %d remaining", n, len(d.data)-d.pos)
+	}
+	out := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return out, nil
+}
+
+func (d *abiDecoder) readUvarint() (uint64, error) {
+	v, n := binary.Uvarint(d.data[d.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid varint at byte %d", d.pos)
+	}
+	d.pos += n
+	return v, nil
+}
+
+func symbolCode(data []byte) string {
+	return string(bytes.TrimRight(data, "\x00"))
+}
+
+// abiObject is a decoded struct, which keeps its fields in ABI order
+// when serialized to JSON.
+type abiObject []abiField
+
+type abiField struct {
+	Name  string
+	Value interface{}
+}
+
+func (o abiObject) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("{")
+	for idx, field := range o {
+		if idx > 0 {
+			buf.WriteString(",")
+		}
+		name, err := json.Marshal(field.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+// redactABIMemos returns a copy of `obj` with the string `memo` fields
+// redacted.
+func (a *Analyzer) redactABIMemos(obj abiObject) abiObject {
+	out := make(abiObject, len(obj))
+	for idx, field := range obj {
+		if memo, ok := field.Value.(string); ok && field.Name == "memo" {
+			field.Value = a.redactMemo(memo)
+		}
+		out[idx] = field
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+package analysis
+
+import (
+	"encoding/hex"
+	"testing"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// testGameABI returns the ABI of a made-up `mygame` contract, with a
+// `move` action.
+func testGameABI() *eos.ABI {
+	return &eos.ABI{
+		Version: "eosio::abi/1.0",
+		Types:   []eos.ABIType{{NewTypeName: "coord", Type: "uint16"}},
+		Structs: []eos.StructDef{
+			{Name: "move", Fields: []eos.FieldDef{{Name: "player", Type: "name"}, {Name: "x", Type: "coord"}, {Name: "tags", Type: "string[]"}}},
+		},
+		Actions: []eos.ActionDef{{Name: "move", Type: "move"}},
+	}
+}
+
+// testMove is the binary counterpart of the `move` struct of
+// testGameABI.
+type testMove struct {
+	Player eos.Name
+	X      uint16
+	Tags   []string
+}
+
+func testMoveData(t *testing.T) []byte {
+	t.Helper()
+	data, err := eos.MarshalBinary(testMove{Player: "alice", X: 7, Tags: []string{"fast", "north"}})
+	if err != nil {
+		t.Fatalf("packing move: %s", err)
+	}
+	return data
+}
+
+func TestAnalyzeRegisteredABI(t *testing.T) {
+	act := testRawAction("mygame", "move", testMoveData(t))
+
+	a := NewAnalyzer(false)
+	a.RegisterABI("mygame", testGameABI())
+	out := testAnalyze(t, a, testTransaction(act))
+	assertContains(t, out, "Data (decoded with registered ABI): {\n  \"player\": \"alice\",\n  \"x\": 7,\n  \"tags\": [\n    \"fast\",\n    \"north\"\n  ]\n}\n")
+
+	out = testAnalyze(t, NewAnalyzer(false), testTransaction(act))
+	assertNotContains(t, out, "registered ABI")
+}
+
+func TestAnalyzeRegisteredABIMismatch(t *testing.T) {
+	act := testRawAction("mygame", "move", append(testMoveData(t), 0xff))
+
+	a := NewAnalyzer(false)
+	a.RegisterABI("mygame", testGameABI())
+	out := testAnalyze(t, a, testTransaction(act))
+	assertContains(t, out, "Couldn't decode data with the registered ABI: 1 trailing bytes\n")
+}
+
+func TestTryDecode(t *testing.T) {
+	transfer := testTransfer(t, "alice", "bob", "1.0000 EOS", "hi")
+	data, err := eos.MarshalBinary(transfer.ActionData.Data)
+	if err != nil {
+		t.Fatalf("packing transfer: %s", err)
+	}
+
+	a := NewAnalyzer(false)
+	cnt, err := a.TryDecode("eosio.token", "transfer", hex.EncodeToString(data))
+	if err != nil {
+		t.Fatalf("TryDecode: %s", err)
+	}
+	if want := `{"from":"alice","to":"bob","quantity":{"Amount":10000,"Precision":4,"Symbol":"EOS"},"memo":"hi"}`; string(cnt) != want {
+		t.Errorf("got %s, want %s", cnt, want)
+	}
+
+	a.RegisterABI("mygame", testGameABI())
+	cnt, err = a.TryDecode("mygame", "move", hex.EncodeToString(testMoveData(t)))
+	if err != nil {
+		t.Fatalf("TryDecode with a registered ABI: %s", err)
+	}
+	if want := `{"player":"alice","x":7,"tags":["fast","north"]}`; string(cnt) != want {
+		t.Errorf("got %s, want %s", cnt, want)
+	}
+
+	if _, err := a.TryDecode("unknown", "doit", "01"); err == nil {
+		t.Error("TryDecode succeeded without an ABI")
+	}
+}
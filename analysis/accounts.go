@@ -0,0 +1,145 @@
+package analysis
+
+import (
+	"sort"
+
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/forum"
+	"github.com/eoscanada/eos-go/msig"
+	"github.com/eoscanada/eos-go/system"
+	"github.com/eoscanada/eos-go/token"
+)
+
+// ReferencedAccounts returns every account `tx` interacts with: the
+// contracts its actions live on, the actors authorizing them, and the
+// accounts named in the data of known actions. The result is sorted
+// and deduplicated.
+func (a *Analyzer) ReferencedAccounts(tx *eos.Transaction) []eos.AccountName {
+	seen := map[eos.AccountName]bool{}
+	out := []eos.AccountName{}
+	add := func(accounts ...eos.AccountName) {
+		for _, acct := range accounts {
+			if acct != "" && !seen[acct] {
+				seen[acct] = true
+				out = append(out, acct)
+			}
+		}
+	}
+
+	for _, actions := range [][]*eos.Action{tx.ContextFreeActions, tx.Actions} {
+		for _, act := range actions {
+			add(act.Account)
+			for _, auth := range act.Authorization {
+				add(auth.Actor)
+			}
+			add(dataAccounts(actionData(act))...)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// dataAccounts returns the accounts named in the decoded action
+// `data`, for the action types that are known.
+func dataAccounts(data interface{}) []eos.AccountName {
+	switch obj := data.(type) {
+	case *system.SetCode:
+		return []eos.AccountName{obj.Account}
+	case *system.SetABI:
+		return []eos.AccountName{obj.Account}
+	case *token.Transfer:
+		return []eos.AccountName{obj.From, obj.To}
+	case *token.Issue:
+		return []eos.AccountName{obj.To}
+	case *Open:
+		return []eos.AccountName{obj.Owner, obj.RAMPayer}
+	case *Close:
+		return []eos.AccountName{obj.Owner}
+	case *system.DelegateBW:
+		return []eos.AccountName{obj.From, obj.Receiver}
+	case *system.UndelegateBW:
+		return []eos.AccountName{obj.From, obj.Receiver}
+	case *system.Refund:
+		return []eos.AccountName{obj.Owner}
+	case *system.RegProducer:
+		return []eos.AccountName{obj.Producer}
+	case *system.RegProxy:
+		return []eos.AccountName{obj.Proxy}
+	case *system.VoteProducer:
+		return append([]eos.AccountName{obj.Voter, obj.Proxy}, obj.Producers...)
+	case *system.ClaimRewards:
+		return []eos.AccountName{obj.Owner}
+	case *system.Bidname:
+		return []eos.AccountName{obj.Bidder}
+	case *msig.Propose:
+		return []eos.AccountName{obj.Proposer}
+	case *WrapExec:
+		return []eos.AccountName{obj.Executer}
+	case *msig.Approve:
+		return []eos.AccountName{obj.Proposer, obj.Level.Actor}
+	case *msig.Exec:
+		return []eos.AccountName{obj.Proposer, obj.Executer}
+	case *msig.Cancel:
+		return []eos.AccountName{obj.Proposer, obj.Canceler}
+	case *Invalidate:
+		return []eos.AccountName{obj.Account}
+	case *system.NewAccount:
+		return []eos.AccountName{obj.Creator, obj.Name}
+	case *system.UpdateAuth:
+		return []eos.AccountName{obj.Account}
+	case *system.SetPriv:
+		return []eos.AccountName{obj.Account}
+	case *SetALimits:
+		return []eos.AccountName{obj.Account}
+	case *forum.Post:
+		return []eos.AccountName{obj.Account, obj.ReplyToAccount}
+	case *forum.Vote:
+		return []eos.AccountName{obj.Voter}
+	case *forum.Remove:
+		return []eos.AccountName{obj.Account}
+	case *Unpost:
+		return []eos.AccountName{obj.Poster}
+	case *ForumPropose:
+		return []eos.AccountName{obj.Proposer}
+	case *DeleteAuth:
+		return []eos.AccountName{obj.Account}
+	case *system.BuyRAM:
+		return []eos.AccountName{obj.Payer, obj.Receiver}
+	case *system.BuyRAMBytes:
+		return []eos.AccountName{obj.Payer, obj.Receiver}
+	case *SellRAM:
+		return []eos.AccountName{obj.Account}
+	case *PowerUp:
+		return []eos.AccountName{obj.Payer, obj.Receiver}
+	case *RentCPU:
+		return []eos.AccountName{obj.From, obj.Receiver}
+	case *RentNet:
+		return []eos.AccountName{obj.From, obj.Receiver}
+	case *LinkAuth:
+		return []eos.AccountName{obj.Account, obj.Code}
+	case *UnlinkAuth:
+		return []eos.AccountName{obj.Account, obj.Code}
+	}
+
+	return nil
+}
+
+// Contracts returns the accounts whose code the actions of `tx` run
+// on, sorted and deduplicated. Unlike `ReferencedAccounts()`, it
+// leaves out authorizers and accounts named in action data.
+func (a *Analyzer) Contracts(tx *eos.Transaction) []eos.AccountName {
+	seen := map[eos.AccountName]bool{}
+	out := []eos.AccountName{}
+	for _, actions := range [][]*eos.Action{tx.ContextFreeActions, tx.Actions} {
+		for _, act := range actions {
+			if !seen[act.Account] {
+				seen[act.Account] = true
+				out = append(out, act.Account)
+			}
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
@@ -0,0 +1,36 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/system"
+)
+
+func TestReferencedAccounts(t *testing.T) {
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", ""),
+		system.NewDelegateBW("carol", "dave", testAsset(t, "1.0000 EOS"), testAsset(t, "1.0000 EOS"), false),
+	)
+
+	got := NewAnalyzer(false).ReferencedAccounts(tx)
+	want := []eos.AccountName{"alice", "bob", "carol", "dave", "eosio", "eosio.token"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestContracts(t *testing.T) {
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", ""),
+		system.NewVoteProducer("alice", "", "bp1"),
+		testTransfer(t, "bob", "alice", "1.0000 EOS", ""),
+	)
+
+	got := NewAnalyzer(false).Contracts(tx)
+	want := []eos.AccountName{"eosio", "eosio.token"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,211 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/system"
+)
+
+// Register the actions that the vendored `eos-go` packages either
+// don't know about, or know about but don't register, so that
+// `Unpack` decodes them too.
+func init() {
+	eos.RegisterAction(eos.AN("eosio"), eos.ActN("updateauth"), system.UpdateAuth{})
+	eos.RegisterAction(eos.AN("eosio"), eos.ActN("deleteauth"), DeleteAuth{})
+	eos.RegisterAction(eos.AN("eosio"), eos.ActN("sellram"), SellRAM{})
+	eos.RegisterAction(eos.AN("eosio"), eos.ActN("linkauth"), LinkAuth{})
+	eos.RegisterAction(eos.AN("eosio"), eos.ActN("unlinkauth"), UnlinkAuth{})
+	eos.RegisterAction(eos.AN("eosio"), eos.ActN("setpriv"), system.SetPriv{})
+	eos.RegisterAction(eos.AN("eosio"), eos.ActN("setparams"), SetParams{})
+	eos.RegisterAction(eos.AN("eosio"), eos.ActN("setalimits"), SetALimits{})
+	eos.RegisterAction(eos.AN("eosio"), eos.ActN("bidname"), system.Bidname{})
+	eos.RegisterAction(eos.AN("eosio"), eos.ActN("powerup"), PowerUp{})
+	eos.RegisterAction(eos.AN("eosio"), eos.ActN("rentcpu"), RentCPU{})
+	eos.RegisterAction(eos.AN("eosio"), eos.ActN("rentnet"), RentNet{})
+	eos.RegisterAction(eos.AN("eosio.msig"), eos.ActN("invalidate"), Invalidate{})
+	eos.RegisterAction(eos.AN("eosio.wrap"), eos.ActN("exec"), WrapExec{})
+	eos.RegisterAction(eos.AN("eosforumtest"), eos.ActN("unpost"), Unpost{})
+	eos.RegisterAction(eos.AN("eosforumtest"), eos.ActN("propose"), ForumPropose{})
+	eos.RegisterAction(eos.AN("eosio.token"), eos.ActN("retire"), Retire{})
+	eos.RegisterAction(eos.AN("eosio.token"), eos.ActN("open"), Open{})
+	eos.RegisterAction(eos.AN("eosio.token"), eos.ActN("close"), Close{})
+}
+
+// unpackActionData decodes the HexData of `act` into its registered
+// type, the same way `Unpack` does for packed transactions. Actions
+// with no registered type are left untouched.
+func unpackActionData(act *eos.Action) error {
+	objType := eos.RegisteredActions[act.Account][act.Name]
+	if objType == nil {
+		return nil
+	}
+
+	obj := reflect.New(objType)
+	if err := eos.UnmarshalBinary(act.HexData, obj.Interface()); err != nil {
+		return fmt.Errorf("decoding action %s::%s, %s", act.Account, act.Name, err)
+	}
+	act.ActionData.Data = obj.Interface()
+
+	return nil
+}
+
+// DeleteAuth represents the `eosio::deleteauth` action.
+type DeleteAuth struct {
+	Account    eos.AccountName    `json:"account"`
+	Permission eos.PermissionName `json:"permission"`
+}
+
+// SellRAM represents the `eosio::sellram` action.
+type SellRAM struct {
+	Account eos.AccountName `json:"account"`
+	Bytes   uint64          `json:"bytes"` // an int64 on chain, which `eos-go` doesn't decode
+}
+
+// LinkAuth represents the `eosio::linkauth` action.
+type LinkAuth struct {
+	Account     eos.AccountName    `json:"account"`
+	Code        eos.AccountName    `json:"code"`
+	Type        eos.ActionName     `json:"type"`
+	Requirement eos.PermissionName `json:"requirement"`
+}
+
+// UnlinkAuth represents the `eosio::unlinkauth` action.
+type UnlinkAuth struct {
+	Account eos.AccountName `json:"account"`
+	Code    eos.AccountName `json:"code"`
+	Type    eos.ActionName  `json:"type"`
+}
+
+// SetParams represents the `eosio::setparams` action.
+type SetParams struct {
+	Params system.BlockchainParameters `json:"params"`
+}
+
+// SetALimits represents the `eosio::setalimits` action. The limits
+// are int64s on chain, which `eos-go` doesn't decode, where -1 means
+// unlimited.
+type SetALimits struct {
+	Account   eos.AccountName `json:"account"`
+	RAMBytes  uint64          `json:"ram_bytes"`
+	NetWeight uint64          `json:"net_weight"`
+	CPUWeight uint64          `json:"cpu_weight"`
+}
+
+// Invalidate represents the `eosio.msig::invalidate` action, which
+// voids all the approvals `Account` gave to pending proposals.
+type Invalidate struct {
+	Account eos.AccountName `json:"account"`
+}
+
+// WrapExec represents the `eosio.wrap::exec` action, which executes
+// `Transaction` with whatever authorizations it declares, without
+// their permissions being checked.
+type WrapExec struct {
+	Executer    eos.AccountName  `json:"executer"`
+	Transaction *eos.Transaction `json:"trx"`
+}
+
+// Unpost represents the forum `unpost` action, which later forum
+// contracts have in place of `remove`.
+type Unpost struct {
+	Poster   eos.AccountName `json:"poster"`
+	PostUUID string          `json:"post_uuid"`
+}
+
+// ForumPropose represents the forum `propose` action, opening a
+// proposal for the community to vote on.
+type ForumPropose struct {
+	Proposer     eos.AccountName `json:"proposer"`
+	ProposalName eos.Name        `json:"proposal_name"`
+	Title        string          `json:"title"`
+	ProposalJSON string          `json:"proposal_json"`
+	ExpiresAt    eos.JSONTime    `json:"expires_at"`
+}
+
+// Retire represents the `eosio.token::retire` action.
+type Retire struct {
+	Quantity eos.Asset `json:"quantity"`
+	Memo     string    `json:"memo"`
+}
+
+// Open represents the `eosio.token::open` action.
+type Open struct {
+	Owner    eos.AccountName `json:"owner"`
+	Symbol   Symbol          `json:"symbol"`
+	RAMPayer eos.AccountName `json:"ram_payer"`
+}
+
+// Close represents the `eosio.token::close` action.
+type Close struct {
+	Owner  eos.AccountName `json:"owner"`
+	Symbol Symbol          `json:"symbol"`
+}
+
+// powerUpFracMax is the `net_frac` and `cpu_frac` of a `powerup`
+// renting all of a resource.
+const powerUpFracMax = 1e15
+
+// PowerUp represents the `eosio::powerup` action, renting a fraction
+// of the chain's NET and CPU for `Days`.
+type PowerUp struct {
+	Payer      eos.AccountName `json:"payer"`
+	Receiver   eos.AccountName `json:"receiver"`
+	Days       uint32          `json:"days"`
+	NetFrac    uint64          `json:"net_frac"` // an int64 on chain, which `eos-go` doesn't decode
+	CPUFrac    uint64          `json:"cpu_frac"` // same
+	MaxPayment eos.Asset       `json:"max_payment"`
+}
+
+// RentCPU represents the REX `eosio::rentcpu` action.
+type RentCPU struct {
+	From        eos.AccountName `json:"from"`
+	Receiver    eos.AccountName `json:"receiver"`
+	LoanPayment eos.Asset       `json:"loan_payment"`
+	LoanFund    eos.Asset       `json:"loan_fund"`
+}
+
+// RentNet represents the REX `eosio::rentnet` action.
+type RentNet struct {
+	From        eos.AccountName `json:"from"`
+	Receiver    eos.AccountName `json:"receiver"`
+	LoanPayment eos.Asset       `json:"loan_payment"`
+	LoanFund    eos.Asset       `json:"loan_fund"`
+}
+
+// Symbol is a token symbol as packed on chain: its precision followed
+// by up to 7 characters, which isn't how `eos.Symbol` packs.
+type Symbol [8]byte
+
+func (s Symbol) String() string {
+	return fmt.Sprintf("%d,%s", s[0], strings.TrimRight(string(s[1:]), "\x00"))
+}
+
+func (s Symbol) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON reads symbols in their `4,EOS` form.
+func (s *Symbol) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	parts := strings.SplitN(str, ",", 2)
+	if len(parts) != 2 || len(parts[1]) > 7 {
+		return fmt.Errorf("invalid symbol %q, expected precision,CODE", str)
+	}
+	precision, err := strconv.ParseUint(parts[0], 10, 8)
+	if err != nil {
+		return fmt.Errorf("invalid symbol precision %q", parts[0])
+	}
+
+	*s = Symbol{byte(precision)}
+	copy(s[1:], parts[1])
+	return nil
+}
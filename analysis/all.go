@@ -0,0 +1,47 @@
+package analysis
+
+import (
+	eos "github.com/eoscanada/eos-go"
+)
+
+// AnalyzeAll runs the full suite of analyses on `trx`: the packed
+// transaction with its header and actions, its signatures (as signed
+// for `chainID`), resources, timeline, and a summary. It ends with a
+// recap of the warnings raised along the way.
+func (a *Analyzer) AnalyzeAll(trx *eos.PackedTransaction, chainID eos.SHA256Bytes) error {
+	warnings := len(a.Warnings)
+
+	sTx, err := a.analyzePacked(trx)
+	if err != nil {
+		return err
+	}
+	tx := sTx.Transaction
+
+	if a.shows(SectionHeader) {
+		a.AnalyzeResources(tx)
+		a.AnalyzeTimeline(tx)
+	}
+
+	if err := a.AnalyzeSignatures(trx, chainID); err != nil {
+		return err
+	}
+
+	if a.shows(SectionSummary) {
+		a.section("SUMMARY")
+		a.Pf("Classification: %s\n", a.Classify(tx))
+		a.Pf("Actions: %s\n", actionCensus(tx))
+		a.AnalyzeAuthorizations(tx)
+		a.CheckAuthorizationCoverage(tx)
+		a.AnalyzeTransferTotals(tx)
+		a.AnalyzeDeployment(tx)
+	}
+
+	a.section("WARNINGS")
+	raised := a.Warnings[warnings:]
+	a.Pf("Warnings: %d\n", len(raised))
+	for _, warning := range raised {
+		a.Pf("- %s\n", warning)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package analysis
+
+import (
+	"encoding/hex"
+	"testing"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+func TestAnalyzeAll(t *testing.T) {
+	chainID, _ := hex.DecodeString(testChainID)
+	unauthorized := testTransfer(t, "alice", "bob", "1.0000 EOS", "")
+	unauthorized.Authorization = nil
+	trx := testPack(t, testTransaction(unauthorized))
+
+	key := testKey(t)
+	sig, err := key.Sign(eos.SigDigest(chainID, trx.PackedTransaction, trx.PackedContextFreeData))
+	if err != nil {
+		t.Fatalf("signing: %s", err)
+	}
+	trx.Signatures = append(trx.Signatures, sig)
+
+	a := NewAnalyzer(false)
+	a.NoBanners = true
+	if err := a.AnalyzeAll(trx, chainID); err != nil {
+		t.Fatalf("AnalyzeAll: %s", err)
+	}
+	out := a.String()
+	for _, section := range []string{"PACKED TRANSACTION", "TRANSACTION HEADER", "ACTIONS", "RESOURCES", "TIMELINE", "SIGNATURES", "SUMMARY", "WARNINGS"} {
+		assertContains(t, out, "== "+section+" ==\n")
+	}
+	assertContains(t, out, "1. "+sig.String()+", signed by: "+key.PublicKey().String()+"\n")
+	assertContains(t, out, "Classification: ")
+	assertContains(t, out, "Warnings: 2\n- WARNING: action has no authorization\n- WARNING: action 1 (eosio.token::transfer) isn't authorized by alice\n")
+}
@@ -3,95 +3,494 @@ package analysis
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"reflect"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/davecgh/go-spew/spew"
 	eos "github.com/eoscanada/eos-go"
-	// Load these so `Unpack` does Action unpacking with known ABIs.
-	_ "github.com/eoscanada/eos-go/forum"
-	_ "github.com/eoscanada/eos-go/msig"
+	// Besides providing their types, these register their actions, so
+	// that `Unpack` decodes them.
+	"github.com/eoscanada/eos-go/forum"
+	"github.com/eoscanada/eos-go/msig"
 	"github.com/eoscanada/eos-go/system"
-	_ "github.com/eoscanada/eos-go/token"
+	"github.com/eoscanada/eos-go/token"
 )
 
 type Analyzer struct {
 	Verbose bool
-	Writer  *bytes.Buffer
+	Writer  io.Writer
+
+	// ExpirationWarning is how close to its expiration a
+	// transaction must be for a warning to be printed.
+	ExpirationWarning time.Duration
+
+	// Color wraps section headers, warnings and action names in ANSI
+	// color codes. Whether the Writer is a terminal is left to the
+	// caller to determine.
+	Color bool
+
+	// Sections selects the parts of the analysis that get printed.
+	// 0 prints them all.
+	Sections Section
+
+	// NoBanners replaces the three-line banners heading each section
+	// with a single `== TITLE ==` line.
+	NoBanners bool
+
+	// TimeFormat is the layout times are printed with, as taken by
+	// `time.Time.Format()`. Defaults to `time.Time.String()`'s.
+	TimeFormat string
+
+	// TimeLocation is the time zone times are printed in. Defaults
+	// to the one they come in, which is UTC for times off the chain.
+	TimeLocation *time.Location
+
+	// MaxDumpBytes truncates the byte blobs dumped in verbose mode
+	// to that many bytes. 0 means unlimited.
+	MaxDumpBytes int
+
+	// ShowRawData appends the raw hex data of decoded actions to their
+	// line, truncated to MaxDumpBytes. When redacting memos, it's left
+	// out for the actions carrying one, or that may (those decoded with
+	// a registered ABI).
+	ShowRawData bool
+
+	// EmbeddedTransactionFields maps `account::name` actions to the
+	// fields of their data holding a transaction (packed, or as an
+	// object), to be analyzed as nested transactions.
+	EmbeddedTransactionFields map[string][]string
+
+	// RedactMemos replaces memos with `[redacted, N chars]` in the
+	// output, for them to stay out of logs. This covers the `memo`
+	// fields of actions decoded with a registered ABI, and verbose dumps
+	// of the packed transaction are left out. The raw data of actions
+	// that can't be decoded at all, and the structured results (JSON,
+	// YAML, ...), aren't redacted.
+	RedactMemos bool
+
+	// MemoRedactor, when set, scrubs memos before they're printed,
+	// instead of the RedactMemos default.
+	MemoRedactor func(memo string) string
+
+	// MaxOutputBytes caps how much gets written to the Writer, past
+	// which the output is cut with a truncation line. 0 means
+	// unlimited. Counted since the last `Reset()`.
+	MaxOutputBytes int
+
+	// HexDump dumps byte blobs as `hexdump -C` does (offset, hex and
+	// ASCII columns) instead of through spew.
+	HexDump bool
+
+	// MaxDepth limits how many levels of nested transactions (like
+	// msig proposals) get expanded. 0 means unlimited.
+	MaxDepth int
+
+	// MaxInputBytes caps how much `AnalyzePackedReader` reads before
+	// giving up. 0 means unlimited.
+	MaxInputBytes int64
+
+	// Indent is what nested output (like authorities, or the
+	// transaction of an msig proposal) gets offset with, once per
+	// level.
+	Indent string
+
+	// ActionFilter, when set, restricts the analysis of a
+	// transaction's actions to the ones for which it returns true.
+	// The others are only counted.
+	ActionFilter func(act *eos.Action) bool
+
+	// ChainMaxNetBytes and ChainMaxCPUMS are the chain's maximum
+	// NET and CPU usage per transaction, which `AnalyzeResources()`
+	// compares caps against. 0 means unknown.
+	ChainMaxNetBytes uint64
+	ChainMaxCPUMS    uint64
+
+	// Compact prints a single header line per transaction, and a
+	// single line per action, without the section banners.
+	Compact bool
+
+	// Summary prints a census of the action types at the end of
+	// each transaction.
+	Summary bool
+
+	// Strict makes the analysis fail on any action that couldn't be
+	// decoded with a known ABI, instead of skipping over its data.
+	Strict bool
+
+	// AccumulateErrors keeps analyzing past actions that fail to
+	// decode, printing the errors inline and collecting them in
+	// Errors, instead of stopping at the first one.
+	AccumulateErrors bool
+
+	// Errors collects the action errors seen in AccumulateErrors
+	// mode. Cleared by `Reset()`.
+	Errors []error
+
+	// Anchors prefixes each action with a `[action:N]` tag (or
+	// `[cfaction:N]` for context-free actions), N being its
+	// zero-based index, for tooling to refer to.
+	Anchors bool
+
+	// Warnings collects every warning (and critical) line printed,
+	// without colors, for callers to inspect. Cleared by `Reset()`.
+	Warnings []string
+
+	// AccountLabels annotates notable accounts (exchanges, system
+	// accounts, ...) with a label, printed next to them.
+	AccountLabels map[eos.AccountName]string
+
+	// PrivilegedAccounts lists the accounts for which a `setcode` or
+	// `setabi` is reported as critical. Defaults to
+	// DefaultPrivilegedAccounts.
+	PrivilegedAccounts []eos.AccountName
+
+	// KnownAccounts, when set, lists the contracts actions are expected
+	// to target. Actions on any other account are warned about, as a
+	// guard against typos in contract names.
+	KnownAccounts map[eos.AccountName]bool
+
+	// CodeHasher, when set, replaces SHA256 as the hash the code of
+	// `setcode` actions is fingerprinted (and matched against
+	// KnownCodeHashes) with.
+	CodeHasher func() hash.Hash
+
+	// KnownCodeHashes maps the hash (in lowercase hex) of audited
+	// contract builds to a label. When set, the code of `setcode`
+	// actions is checked against it.
+	KnownCodeHashes map[string]string
+
+	// SignatureColumns lays out `AnalyzeSignatures` as two columns, each
+	// signature next to the public key it recovers to.
+	SignatureColumns bool
+
+	// ABIFetcher, when set, is used to retrieve the ABI currently
+	// deployed on an account, so `setabi` actions can be diffed
+	// against it.
+	ABIFetcher func(account eos.AccountName) (*eos.ABI, error)
+
+	indent  string
+	midLine bool
+	depth   int
+	abis    map[eos.AccountName]*eos.ABI
+
+	// written counts the bytes output so far, against MaxOutputBytes.
+	written   int
+	truncated bool
+
+	// decoders holds the functions registered with `RegisterDecoder`.
+	decoders map[actionKey]func(*eos.Action) string
+
+	// requiredPermissions holds the permissions registered with
+	// `RegisterRequiredPermission`.
+	requiredPermissions map[actionKey]eos.PermissionLevel
+
+	// actionErrors holds the decoding errors met before analysis
+	// started, to be reported along with their action.
+	actionErrors map[*eos.Action]error
 }
 
+// NewAnalyzer returns an Analyzer writing to an in-memory buffer,
+// retrievable with `String()`.
 func NewAnalyzer(verbose bool) *Analyzer {
+	return NewAnalyzerWithWriter(verbose, &bytes.Buffer{})
+}
+
+// NewAnalyzerWithWriter returns an Analyzer streaming its output to
+// `w`.
+func NewAnalyzerWithWriter(verbose bool, w io.Writer) *Analyzer {
 	return &Analyzer{
-		Verbose: verbose,
-		Writer:  &bytes.Buffer{},
+		Verbose:            verbose,
+		Writer:             w,
+		ExpirationWarning:  30 * time.Second,
+		MaxDepth:           3,
+		Indent:             "  ",
+		MaxInputBytes:      1 << 20,
+		PrivilegedAccounts: append([]eos.AccountName{}, DefaultPrivilegedAccounts...),
 	}
 }
 
+// Section identifies a part of the analysis, for Analyzer.Sections.
+// Sections combine as a bitmask.
+type Section uint
+
+const (
+	// SectionHeader covers the banners and the headers of the
+	// packed and unpacked transaction.
+	SectionHeader Section = 1 << iota
+	// SectionActions covers the actions and their decoded data.
+	SectionActions
+	// SectionSignatures covers the signatures and their recovered
+	// keys.
+	SectionSignatures
+	// SectionSummary covers the census printed in Summary mode.
+	SectionSummary
+)
+
+// shows returns whether `section` is part of Sections.
+func (a *Analyzer) shows(section Section) bool {
+	return a.Sections == 0 || a.Sections&section != 0
+}
+
+// DefaultPrivilegedAccounts are the system accounts created at boot,
+// which hold special privileges on the chain.
+var DefaultPrivilegedAccounts = []eos.AccountName{
+	"eosio",
+	"eosio.bpay",
+	"eosio.msig",
+	"eosio.names",
+	"eosio.ram",
+	"eosio.ramfee",
+	"eosio.saving",
+	"eosio.stake",
+	"eosio.token",
+	"eosio.vpay",
+	"eosio.wrap",
+}
+
+// String returns the analysis written so far, if the Writer keeps it
+// around (like the default buffer does).
+func (a *Analyzer) String() string {
+	if s, ok := a.Writer.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return ""
+}
+
+// Reset truncates the Writer when it supports it (like the default
+// buffer does), so the Analyzer can be reused for another
+// transaction. Call `String()` before `Reset()` if you need the
+// previous output.
+func (a *Analyzer) Reset() {
+	if r, ok := a.Writer.(interface{ Reset() }); ok {
+		r.Reset()
+	}
+	a.indent = ""
+	a.midLine = false
+	a.depth = 0
+	a.written = 0
+	a.truncated = false
+	a.Warnings = nil
+	a.Errors = nil
+	a.actionErrors = nil
+}
+
 func (a *Analyzer) AnalyzePacked(trx *eos.PackedTransaction) (err error) {
-	a.Pln()
-	a.Pln("---------------------------------------------------------------------")
-	a.Pln("------------------------- PACKED TRANSACTION ------------------------")
-	a.Pln("---------------------------------------------------------------------")
-	a.Pln()
-	a.Pf("Transaction ID: %s\n", trx.ID())
-	a.Pf("Signatures: %q\n", trx.Signatures)
-	a.Pf("Packed context free data length: %d\n", len(trx.PackedContextFreeData))
-	a.VerbDump(trx.PackedContextFreeData)
-	a.Pf("Packed transaction data length: %d\n", len(trx.PackedTransaction))
-	a.VerbDump(trx.PackedContextFreeData)
-	a.Pln()
-	a.Pln("---------------------------------------------------------------------")
-	a.Pln("----------------------- SIGNED TRANSACTION --------------------------")
-	a.Pln("---------------------------------------------------------------------")
-	a.Pln()
+	_, err = a.analyzePacked(trx)
+	return
+}
 
-	sTx, err := trx.Unpack()
+// analyzePacked is `AnalyzePacked()`, also returning the transaction
+// it unpacked for callers to analyze further without unpacking it
+// again.
+func (a *Analyzer) analyzePacked(trx *eos.PackedTransaction) (*eos.SignedTransaction, error) {
+	if a.Compact {
+		sTx, err := a.Unpack(trx)
+		if err != nil {
+			return nil, err
+		}
+		return sTx, a.AnalyzeSignedTransaction(sTx)
+	}
+
+	if a.shows(SectionHeader) {
+		a.section("PACKED TRANSACTION")
+		a.PrintID(trx)
+	}
+	if a.shows(SectionSignatures) {
+		a.Pf("Signatures: %q\n", trx.Signatures)
+	}
+	if a.shows(SectionHeader) {
+		a.Pf("Compression: %s\n", compressionName(trx.Compression))
+		a.Pf("Packed context free data length: %d\n", len(trx.PackedContextFreeData))
+		a.VerbDump(trx.PackedContextFreeData)
+		a.Pf("Packed transaction data length: %d\n", len(trx.PackedTransaction))
+		if a.redactsMemos() {
+			a.VerbPln("(packed transaction dump left out, as memos are redacted)")
+		} else {
+			a.VerbDump(trx.PackedTransaction)
+		}
+		a.section("SIGNED TRANSACTION")
+	}
+
+	sTx, err := a.Unpack(trx)
 	if err != nil {
-		return
+		return nil, err
+	}
+
+	if a.shows(SectionHeader) {
+		a.analyzeContextFreeData(sTx)
+	}
+
+	return sTx, a.AnalyzeSignedTransaction(sTx)
+}
+
+// Unpack decodes `trx`, decompressing it first if needed, for
+// callers to reuse what `AnalyzePacked()` analyzes.
+// `PackedTransaction.Unpack()` decompresses too, but chokes on empty
+// zlib-compressed context-free data.
+//
+// In AccumulateErrors mode, actions are decoded one by one, so that
+// one that fails to decode doesn't fail the whole transaction: its
+// error is reported along with it instead.
+func (a *Analyzer) Unpack(trx *eos.PackedTransaction) (*eos.SignedTransaction, error) {
+	trxData, cfdData, err := signedPayloads(trx)
+	if err != nil {
+		return nil, err
+	}
+
+	var sTx *eos.SignedTransaction
+	if a.AccumulateErrors {
+		if sTx, err = a.unpackEachAction(trx, trxData); err != nil {
+			return nil, err
+		}
+	} else {
+		plain := *trx
+		plain.Compression = eos.CompressionNone
+		plain.PackedTransaction = trxData
+		plain.PackedContextFreeData = cfdData
+		if sTx, err = plain.Unpack(); err != nil {
+			return nil, err
+		}
+	}
+
+	// `Unpack` leaves the context-free data out.
+	if len(cfdData) > 0 {
+		if err := eos.UnmarshalBinary(cfdData, &sTx.ContextFreeData); err != nil {
+			return nil, fmt.Errorf("unpacking context free data, %s", err)
+		}
+	}
+
+	return sTx, nil
+}
+
+// unpackEachAction decodes the transaction packed in `trxData`
+// without its action data, then decodes each action on its own,
+// recording the errors for them to be reported later.
+func (a *Analyzer) unpackEachAction(trx *eos.PackedTransaction, trxData []byte) (*eos.SignedTransaction, error) {
+	decoder := eos.NewDecoder(trxData)
+	decoder.DecodeActions(false)
+
+	var tx eos.Transaction
+	if err := decoder.Decode(&tx); err != nil {
+		return nil, fmt.Errorf("unpacking Transaction, %s", err)
+	}
+
+	for _, actions := range [][]*eos.Action{tx.ContextFreeActions, tx.Actions} {
+		for _, act := range actions {
+			if err := unpackActionData(act); err != nil {
+				a.recordActionError(act, err)
+			}
+		}
 	}
 
+	sTx := eos.NewSignedTransaction(&tx)
+	sTx.Signatures = trx.Signatures
+	return sTx, nil
+}
+
+// recordActionError keeps `err`, met decoding `act` before analysis
+// started, to be reported along with it.
+func (a *Analyzer) recordActionError(act *eos.Action, err error) {
+	if a.actionErrors == nil {
+		a.actionErrors = map[*eos.Action]error{}
+	}
+	a.actionErrors[act] = err
+}
+
+func compressionName(compression eos.CompressionType) string {
+	if name := compression.String(); name != "" {
+		return name
+	}
+	return fmt.Sprintf("unknown (%d)", compression)
+}
+
+func (a *Analyzer) analyzeContextFreeData(sTx *eos.SignedTransaction) {
 	a.Pf("Number of context-free data blobs (on Transaction): %d\n", len(sTx.ContextFreeData))
 	for idx, blob := range sTx.ContextFreeData {
 		a.Pf("%d. Blob length: %d\n", idx+1, len(blob))
 		a.VerbDump(blob)
 	}
 
-	return a.AnalyzeSignedTransaction(sTx)
+	cfActions, cfBlobs := len(sTx.ContextFreeActions), len(sTx.ContextFreeData)
+	if (cfActions == 0) != (cfBlobs == 0) {
+		a.warn("%d context-free action(s) but %d context-free data blob(s)", cfActions, cfBlobs)
+	}
 }
 
 func (a *Analyzer) AnalyzeSignedTransaction(sTx *eos.SignedTransaction) (err error) {
 	return a.AnalyzeTransaction(sTx.Transaction)
 }
 func (a *Analyzer) AnalyzeTransaction(tx *eos.Transaction) (err error) {
+	if a.Compact {
+		a.analyzeCompact(tx)
+		return nil
+	}
 
-	a.Pln()
-	a.Pln("---------------------------------------------------------------------")
-	a.Pln("----------------------- TRANSACTION HEADER --------------------------")
-	a.Pln("---------------------------------------------------------------------")
-	a.Pln()
+	if a.shows(SectionHeader) {
+		a.analyzeHeader(tx)
+	}
+	if a.shows(SectionActions) {
+		if err := a.analyzeActions(tx); err != nil {
+			return err
+		}
+	}
+	if a.Summary && a.shows(SectionSummary) {
+		a.Pf("Summary: %s\n", actionCensus(tx))
+	}
 
+	return nil
+}
+
+func (a *Analyzer) analyzeHeader(tx *eos.Transaction) {
+	a.section("TRANSACTION HEADER")
+
+	if signer := primarySigner(tx); signer != "" {
+		a.Pf("Primary signer (heuristic): %s\n", signer)
+	}
 	now := time.Now().UTC()
-	a.Pf("Expiration: %s (in %s, analysis time: %s)\n", tx.Expiration.Time, tx.Expiration.Time.Sub(now), now)
-	a.Pf("Expiration: %s\n", tx.Expiration.Time)
+	a.Pf("Expiration: %s (%s, analysis time: %s)\n", a.formatTime(tx.Expiration.Time), countdown(tx.Expiration.Time.Sub(now)), a.formatTime(now))
+	a.Pf("Expiration: %s\n", a.formatTime(tx.Expiration.Time))
+	if timeLeft := tx.Expiration.Time.Sub(now); timeLeft < 0 {
+		a.warn("transaction expired %s ago", formatDuration(-timeLeft))
+	} else if timeLeft < a.ExpirationWarning {
+		a.warn("expires in under %s", a.ExpirationWarning)
+	}
 	a.Pf("Reference block number: %d\n", tx.RefBlockNum)
 	a.Pf("Reference block prefix: %x\n", tx.RefBlockPrefix)
 	a.Pf("Maximum net usage words (of 8 bytes, 0 = unlimited): %d\n", tx.MaxNetUsageWords)
 	a.Pf("Maximum CPU usage in milliseconds (0 = unlimited): %d\n", tx.MaxCPUUsageMS)
 	a.Pf("Number of seconds to delay transaction (cancellable during that time): %d\n", tx.DelaySec)
+	if tx.DelaySec > 0 {
+		a.warn("delayed transaction, executes after %ds", tx.DelaySec)
+	}
+}
 
-	a.Pln()
-	a.Pln("---------------------------------------------------------------------")
-	a.Pln("------------------------------ ACTIONS ------------------------------")
-	a.Pln("---------------------------------------------------------------------")
-	a.Pln()
+func (a *Analyzer) analyzeActions(tx *eos.Transaction) error {
+	a.section("ACTIONS")
 
+	if len(tx.ContextFreeActions) > 0 {
+		a.warn("transaction contains %d context-free action(s)", len(tx.ContextFreeActions))
+	}
+	skipped := 0
 	a.Pf("Context-free actions: %d\n", len(tx.ContextFreeActions))
 	for idx, act := range tx.ContextFreeActions {
-		if err := a.analyzeAction(idx, act); err != nil {
-			return err
+		if a.ActionFilter != nil && !a.ActionFilter(act) {
+			skipped++
+			continue
+		}
+		if err := a.analyzeAction(idx, act, true); err != nil {
+			if err = a.actionError(idx, err); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -99,38 +498,199 @@ func (a *Analyzer) AnalyzeTransaction(tx *eos.Transaction) (err error) {
 
 	a.Pf("Actions: %d\n", len(tx.Actions))
 	for idx, act := range tx.Actions {
-		if err := a.analyzeAction(idx, act); err != nil {
-			return err
+		if a.ActionFilter != nil && !a.ActionFilter(act) {
+			skipped++
+			continue
+		}
+		if err := a.analyzeAction(idx, act, false); err != nil {
+			if err = a.actionError(idx, err); err != nil {
+				return err
+			}
 		}
 	}
 
+	if skipped > 0 {
+		a.Pf("Actions skipped by the filter: %d\n", skipped)
+	}
+
+	a.checkDuplicateActions(tx.Actions)
+
+	return nil
+}
+
+// actionError returns `err`, hit while analyzing action `idx`,
+// unless in AccumulateErrors mode where it's reported inline and
+// collected instead.
+func (a *Analyzer) actionError(idx int, err error) error {
+	if !a.AccumulateErrors {
+		return err
+	}
+
+	a.Pf("error decoding action %d: %s\n", idx+1, err)
+	a.Errors = append(a.Errors, err)
+	a.Pln()
 	return nil
 }
 
-func (a *Analyzer) analyzeAction(idx int, act *eos.Action) (err error) {
-	var auths []string
-	for _, auth := range act.Authorization {
-		auths = append(auths, fmt.Sprintf("%s@%s", auth.Actor, auth.Permission))
+// analyzeCompact prints `tx` on one line, followed by one line per
+// action in the form `N) account::name [auths] -> summary`.
+func (a *Analyzer) analyzeCompact(tx *eos.Transaction) {
+	a.Pf("Transaction %s, expiration: %s\n", transactionID(tx), a.formatTime(tx.Expiration.Time))
+
+	line := func(label string, act *eos.Action) {
+		out := fmt.Sprintf("%s %s::%s [%s]", label, act.Account, act.Name, strings.Join(authorizationStrings(act), ", "))
+		if summary := a.summarize(act, actionData(act)); summary != "" {
+			out += " -> " + summary
+		}
+		a.Pln(out)
+	}
+	for idx, act := range tx.ContextFreeActions {
+		line(fmt.Sprintf("cf%d)", idx+1), act)
+	}
+	for idx, act := range tx.Actions {
+		line(fmt.Sprintf("%d)", idx+1), act)
+	}
+}
+
+// primarySigner returns the first authorization of the first
+// action, which usually is whoever put the transaction together.
+func primarySigner(tx *eos.Transaction) string {
+	for _, act := range tx.Actions {
+		if len(act.Authorization) > 0 {
+			return authorizationStrings(act)[0]
+		}
+	}
+	return ""
+}
+
+// transactionID returns the hex-encoded ID of `tx`, which is the
+// sha256 of its packed form.
+func transactionID(tx *eos.Transaction) string {
+	data, err := eos.MarshalBinary(tx)
+	if err != nil {
+		return fmt.Sprintf("(unknown: %s)", err)
+	}
+
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// ID returns the hex-encoded ID of `trx`, which is the sha256 of the
+// packed transaction, once decompressed.
+func ID(trx *eos.PackedTransaction) string {
+	trxData, _, err := signedPayloads(trx)
+	if err != nil {
+		return fmt.Sprintf("(unknown: %s)", err)
+	}
+
+	h := sha256.Sum256(trxData)
+	return hex.EncodeToString(h[:])
+}
+
+// PrintID prints the ID of `trx`, without analyzing it any further.
+func (a *Analyzer) PrintID(trx *eos.PackedTransaction) {
+	a.Pf("Transaction ID: %s\n", ID(trx))
+}
+
+func (a *Analyzer) analyzeAction(idx int, act *eos.Action, contextFree bool) (err error) {
+	if a.Anchors {
+		kind := "action"
+		if contextFree {
+			kind = "cfaction"
+		}
+		a.Pf("[%s:%d] ", kind, idx)
+	}
+	actionName := a.colorize(colorAction, fmt.Sprintf("%s::%s", act.Account, act.Name))
+	if label, ok := a.AccountLabels[act.Account]; ok {
+		actionName += fmt.Sprintf(" (%s)", label)
+	}
+	a.Pf("%d. Action %s, authorized by: %s\n", idx+1, actionName, strings.Join(authorizationStrings(act), ", "))
+	// Context-free actions can't carry authorizations to begin with.
+	if !contextFree && len(act.Authorization) == 0 {
+		a.warn("action has no authorization")
+	}
+	if a.KnownAccounts != nil && !a.KnownAccounts[act.Account] {
+		a.warn("contract %s isn't a known account, check for a typo", act.Account)
+	}
+	if err := a.actionErrors[act]; err != nil {
+		return err
 	}
-	a.Pf("%d. Action %s::%s, authorized by: %s\n", idx+1, act.Account, act.Name, strings.Join(auths, ", "))
 
-	switch obj := act.ActionData.Data.(type) {
+	data := actionData(act)
+	summary := a.summarize(act, data)
+	if summary == "" && data == nil {
+		if ok, err := a.analyzeABIAction(act); ok {
+			if err != nil {
+				if a.Strict || a.AccumulateErrors {
+					return fmt.Errorf("action %s::%s couldn't be decoded with its registered ABI, %s", act.Account, act.Name, err)
+				}
+				a.Pf("Couldn't decode data with the registered ABI: %s\n", err)
+			} else if err := a.analyzeEmbedded(act); err != nil {
+				return err
+			}
+			a.Pln()
+			a.Pln()
+			return nil
+		}
+		if a.Strict {
+			return fmt.Errorf("action %s::%s couldn't be decoded with a known ABI", act.Account, act.Name)
+		}
+		if len(act.HexData) > 0 {
+			a.printRawData(act.HexData)
+			a.Pln()
+			a.Pln()
+		}
+		return nil
+	}
+	if summary != "" {
+		a.Pln(summary + a.rawSuffix(act))
+	} else {
+		a.printDecodedData(act, data)
+	}
+
+	switch obj := data.(type) {
 	case *system.SetCode:
-		a.Pf("Set code for account: %s\n", obj.Account)
+		a.checkPrivileged("code", obj.Account)
 		a.Pf("VM type/version: %d/%d\n", obj.VMType, obj.VMVersion)
-		h := sha256.New()
-		_, _ = h.Write(obj.Code)
-		a.Pf("Code's SHA256: %s\n", hex.EncodeToString(h.Sum(nil)))
+		if len(obj.Code) == 0 {
+			a.Pln("Code: EMPTY (clearing contract)")
+			break
+		}
+		a.Pf("Code size: %d bytes (%.1f KB)\n", len(obj.Code), float64(len(obj.Code))/1024)
+		a.Pf("Code format: %s\n", codeFormat(obj.Code))
+		var codeHash string
+		if a.CodeHasher != nil {
+			h := a.CodeHasher()
+			_, _ = h.Write(obj.Code)
+			codeHash = hex.EncodeToString(h.Sum(nil))
+			a.Pf("Code's hash: %s\n", codeHash)
+		} else {
+			h := sha256.Sum256(obj.Code)
+			codeHash = hex.EncodeToString(h[:])
+			a.Pf("Code's SHA256: %s\n", codeHash)
+		}
+		a.Pf("Code's CRC32: %08x\n", crc32.ChecksumIEEE(obj.Code))
+		if a.KnownCodeHashes != nil {
+			if label, ok := a.KnownCodeHashes[codeHash]; ok {
+				a.Pf("Code matches known build: %s\n", label)
+			} else {
+				a.Pln("Code hash NOT in known set")
+			}
+		}
 		a.Pf("Contains the string 'SYS': %v\n", bytes.Contains(obj.Code, []byte("SYS")))
 		a.Pf("Contains the string 'EOS': %v\n", bytes.Contains(obj.Code, []byte("EOS")))
 		a.VerbDump(obj.Code)
 
 	case *system.SetABI:
-		a.Pf("Set ABI for account: %s\n", obj.Account)
+		a.checkPrivileged("ABI", obj.Account)
 		var unpackedABI eos.ABI
 		if err := eos.UnmarshalBinary(obj.ABI, &unpackedABI); err != nil {
 			a.Pf("Couldn't unpack the ABI therein: %s\n", err)
 		}
+		a.Pln(abiInventory(&unpackedABI))
+		if a.ABIFetcher != nil {
+			a.analyzeABIDiff(obj.Account, &unpackedABI)
+		}
 		jsonABI, err := json.MarshalIndent(unpackedABI, "", "  ")
 		if err != nil {
 			a.Pf("Couldn't serialize ABI into JSON: %s\n", err)
@@ -138,8 +698,86 @@ func (a *Analyzer) analyzeAction(idx int, act *eos.Action) (err error) {
 		a.VerbPln("JSON representation of the ABI:")
 		a.VerbPf("%s\n", string(jsonABI))
 
-	default:
-		return nil
+	case *token.Transfer:
+		if obj.From == obj.To {
+			a.note("self-transfer (from == to)")
+		}
+		if obj.Quantity.Amount == 0 {
+			a.note("zero-quantity transfer")
+		}
+		if !a.redactsMemos() && !isPrintable(obj.Memo) {
+			a.Pf("Memo (hex): %s\n", hex.EncodeToString([]byte(obj.Memo)))
+		}
+
+	case *system.DelegateBW:
+		a.Pf("Transfer ownership of the stake to receiver: %v\n", obj.Transfer)
+
+	case *msig.Propose:
+		a.Pf("Requested approvals: %s\n", strings.Join(permissionLevelStrings(obj.Requested), ", "))
+		if obj.Transaction != nil {
+			a.Pln(">>>>>>>>>>>>>>>>>>>>>>> PROPOSED TRANSACTION >>>>>>>>>>>>>>>>>>>>>>>")
+			if err := a.analyzeNested(obj.Transaction); err != nil {
+				return err
+			}
+			a.Pln("<<<<<<<<<<<<<<<<<<<<< END PROPOSED TRANSACTION <<<<<<<<<<<<<<<<<<<<<")
+		}
+
+	case *WrapExec:
+		a.critical("eosio.wrap exec (privileged bypass)")
+		if obj.Transaction != nil {
+			a.Pln(">>>>>>>>>>>>>>>>>>>>>>> WRAPPED TRANSACTION >>>>>>>>>>>>>>>>>>>>>>>>")
+			if err := a.analyzeNested(obj.Transaction); err != nil {
+				return err
+			}
+			a.Pln("<<<<<<<<<<<<<<<<<<<<<< END WRAPPED TRANSACTION <<<<<<<<<<<<<<<<<<<<<<")
+		}
+
+	case *system.RegProducer:
+		a.Pf("Producer key: %s\n", obj.ProducerKey)
+		a.Pf("URL: %s\n", obj.URL)
+		a.Pf("Location: %d\n", obj.Location)
+
+	case *SetParams:
+		params, err := json.MarshalIndent(obj.Params, "", "  ")
+		if err != nil {
+			a.Pf("Couldn't serialize parameters into JSON: %s\n", err)
+		}
+		a.Pf("Parameters: %s\n", string(params))
+
+	case *SetALimits:
+		a.Pf("RAM bytes: %s\n", resourceLimit(obj.RAMBytes))
+		a.Pf("NET weight: %s\n", resourceLimit(obj.NetWeight))
+		a.Pf("CPU weight: %s\n", resourceLimit(obj.CPUWeight))
+
+	case *forum.Post:
+		a.Pf("Post UUID: %s\n", obj.PostUUID)
+		if obj.ReplyToAccount != "" {
+			a.Pf("In reply to: %s's post %s\n", obj.ReplyToAccount, obj.ReplyToPostUUID)
+		}
+		a.Pf("Content: %q\n", truncate(obj.Content, forumContentLength))
+		a.Pf("Certify: %v\n", obj.Certify)
+		if obj.JSONMetadata != "" {
+			a.Pf("JSON metadata: %s\n", truncate(obj.JSONMetadata, forumContentLength))
+		}
+
+	case *forum.Vote:
+		a.Pf("Proposition hash: %s\n", obj.PropositionHash)
+
+	case *ForumPropose:
+		a.Pf("Expires at: %s\n", a.formatTime(obj.ExpiresAt.Time))
+		if obj.ProposalJSON != "" {
+			a.Pf("Proposal metadata: %s\n", truncate(obj.ProposalJSON, forumContentLength))
+		}
+
+	case *system.NewAccount:
+		a.printAuthority("Owner", obj.Owner)
+		a.printAuthority("Active", obj.Active)
+
+	case *system.UpdateAuth:
+		a.printAuthority("New", obj.Auth)
+	}
+	if err := a.analyzeEmbedded(act); err != nil {
+		return err
 	}
 	a.Pln()
 	a.Pln()
@@ -147,38 +785,656 @@ func (a *Analyzer) analyzeAction(idx int, act *eos.Action) (err error) {
 	return nil
 }
 
+// actionSummary returns a one-line description of the decoded action
+// `data`, or an empty string when its type isn't known.
+func (a *Analyzer) actionSummary(data interface{}) string {
+	switch obj := data.(type) {
+	case *system.SetCode:
+		return fmt.Sprintf("Set code for account: %s", obj.Account)
+
+	case *system.SetABI:
+		return fmt.Sprintf("Set ABI for account: %s", obj.Account)
+
+	case *token.Transfer:
+		return fmt.Sprintf("Transfer %s from %s to %s, memo: %s", obj.Quantity, a.labeled(obj.From), a.labeled(obj.To), a.memo(obj.Memo))
+
+	case *token.Issue:
+		return fmt.Sprintf("Issue %s to %s, memo: %s", obj.Quantity, a.labeled(obj.To), a.memo(obj.Memo))
+
+	case *Retire:
+		return fmt.Sprintf("Retire %s, memo: %s", obj.Quantity, a.memo(obj.Memo))
+
+	case *Open:
+		return fmt.Sprintf("Open a %s balance for %s, RAM paid by %s", obj.Symbol, obj.Owner, obj.RAMPayer)
+
+	case *Close:
+		return fmt.Sprintf("Close the %s balance of %s", obj.Symbol, obj.Owner)
+
+	case *system.DelegateBW:
+		return fmt.Sprintf("Delegate %s for NET and %s for CPU from %s to %s", obj.StakeNet, obj.StakeCPU, obj.From, obj.Receiver)
+
+	case *system.UndelegateBW:
+		return fmt.Sprintf("Undelegate %s for NET and %s for CPU staked by %s to %s", obj.UnstakeNet, obj.UnstakeCPU, obj.From, obj.Receiver)
+
+	case *system.Refund:
+		return fmt.Sprintf("Refund unstaked tokens to %s", obj.Owner)
+
+	case *system.RegProducer:
+		return fmt.Sprintf("Register producer %s", obj.Producer)
+
+	case *system.RegProxy:
+		if obj.IsProxy {
+			return fmt.Sprintf("Register %s as a voting proxy", obj.Proxy)
+		}
+		return fmt.Sprintf("Unregister %s as a voting proxy", obj.Proxy)
+
+	case *system.VoteProducer:
+		// A proxy vote carries no producers, and the other way around.
+		if obj.Proxy != "" {
+			return fmt.Sprintf("Vote: %s votes via proxy %s", obj.Voter, obj.Proxy)
+		}
+		return fmt.Sprintf("Vote: %s votes directly for %d producer(s): %s", obj.Voter, len(obj.Producers), joinAccountNames(obj.Producers))
+
+	case *system.ClaimRewards:
+		return fmt.Sprintf("Claim producer rewards for %s", obj.Owner)
+
+	case *system.Bidname:
+		return fmt.Sprintf("Bid %s by %s on the name %s", obj.Bid, obj.Bidder, obj.Newname)
+
+	case *msig.Propose:
+		return fmt.Sprintf("Proposal %q by %s", obj.ProposalName, obj.Proposer)
+
+	case *WrapExec:
+		return fmt.Sprintf("Execute a wrapped transaction, by %s", obj.Executer)
+
+	case *msig.Approve:
+		return fmt.Sprintf("Approve proposal %q by %s, with authority: %s@%s", obj.ProposalName, obj.Proposer, obj.Level.Actor, obj.Level.Permission)
+
+	case *msig.Exec:
+		return fmt.Sprintf("Execute proposal %q by %s, executed by: %s", obj.ProposalName, obj.Proposer, obj.Executer)
+
+	case *msig.Cancel:
+		return fmt.Sprintf("Cancel proposal %q by %s, canceled by: %s", obj.ProposalName, obj.Proposer, obj.Canceler)
+
+	case *Invalidate:
+		return fmt.Sprintf("Invalidate all approvals given by %s", obj.Account)
+
+	case *system.NewAccount:
+		return fmt.Sprintf("New account %s, created by %s", obj.Name, obj.Creator)
+
+	case *system.UpdateAuth:
+		return fmt.Sprintf("Update permission %s@%s, parent: %s", obj.Account, obj.Permission, obj.Parent)
+
+	case *system.SetPriv:
+		return fmt.Sprintf("Set privileged status of %s to %v", obj.Account, obj.IsPriv)
+
+	case *SetParams:
+		return "Set blockchain parameters"
+
+	case *SetALimits:
+		return fmt.Sprintf("Set resource limits of %s", obj.Account)
+
+	case *forum.Post:
+		return fmt.Sprintf("Forum post %q by %s", obj.Title, obj.Account)
+
+	case *forum.Vote:
+		return fmt.Sprintf("Forum vote by %s on proposition %q: %q", obj.Voter, obj.Proposition, obj.VoteValue)
+
+	case *forum.Remove:
+		return fmt.Sprintf("Remove forum post %s by %s", obj.PostUUID, obj.Account)
+
+	case *Unpost:
+		return fmt.Sprintf("Remove forum post %s by %s", obj.PostUUID, obj.Poster)
+
+	case *ForumPropose:
+		return fmt.Sprintf("Forum proposal %q (%s) by %s", obj.Title, obj.ProposalName, obj.Proposer)
+
+	case *DeleteAuth:
+		return fmt.Sprintf("Delete permission %s@%s", obj.Account, obj.Permission)
+
+	case *system.BuyRAM:
+		return fmt.Sprintf("Buy RAM worth %s for %s, paid by %s", obj.Quantity, obj.Receiver, obj.Payer)
+
+	case *system.BuyRAMBytes:
+		return fmt.Sprintf("Buy %d bytes of RAM for %s, paid by %s", obj.Bytes, obj.Receiver, obj.Payer)
+
+	case *SellRAM:
+		return fmt.Sprintf("Sell %d bytes of RAM from %s", obj.Bytes, obj.Account)
+
+	case *PowerUp:
+		return fmt.Sprintf("Power up %s for %d days with %.4f%% of NET and %.4f%% of CPU, paid by %s (up to %s)",
+			obj.Receiver, obj.Days, float64(obj.NetFrac)*100/powerUpFracMax, float64(obj.CPUFrac)*100/powerUpFracMax, obj.Payer, obj.MaxPayment)
+
+	case *RentCPU:
+		return fmt.Sprintf("Rent CPU for %s, paying %s from %s (%s added to the loan fund)", obj.Receiver, obj.LoanPayment, obj.From, obj.LoanFund)
+
+	case *RentNet:
+		return fmt.Sprintf("Rent NET for %s, paying %s from %s (%s added to the loan fund)", obj.Receiver, obj.LoanPayment, obj.From, obj.LoanFund)
+
+	case *LinkAuth:
+		return fmt.Sprintf("linkauth: %s links %s::%s to permission '%s'", obj.Account, obj.Code, obj.Type, obj.Requirement)
+
+	case *UnlinkAuth:
+		return fmt.Sprintf("unlinkauth: %s unlinks %s::%s from its required permission", obj.Account, obj.Code, obj.Type)
+	}
+
+	return ""
+}
+
+// checkPrivileged flags the deployment of `what` to `account` when
+// it is one of the PrivilegedAccounts.
+func (a *Analyzer) checkPrivileged(what string, account eos.AccountName) {
+	for _, acct := range a.PrivilegedAccounts {
+		if acct == account {
+			a.critical("deploying %s to privileged system account %s", what, account)
+			return
+		}
+	}
+}
+
+// resourceLimit formats the int64 `limit` decoded as a uint64, -1
+// meaning unlimited.
+func resourceLimit(limit uint64) string {
+	if int64(limit) < 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d", limit)
+}
+
+// memo formats `memo` for printing, quoted, or redacted when
+// RedactMemos or MemoRedactor say so.
+func (a *Analyzer) memo(memo string) string {
+	if a.MemoRedactor != nil {
+		return fmt.Sprintf("%q", a.MemoRedactor(memo))
+	}
+	if a.RedactMemos {
+		return a.redactMemo(memo)
+	}
+	return fmt.Sprintf("%q", memo)
+}
+
+// redactMemo returns what replaces `memo` when redacting memos.
+func (a *Analyzer) redactMemo(memo string) string {
+	if a.MemoRedactor != nil {
+		return a.MemoRedactor(memo)
+	}
+	return fmt.Sprintf("[redacted, %d chars]", utf8.RuneCountInString(memo))
+}
+
+// hasMemo returns whether the decoded action `data` carries a memo.
+func hasMemo(data interface{}) bool {
+	switch data.(type) {
+	case *token.Transfer, *token.Issue, *Retire:
+		return true
+	}
+	return false
+}
+
+func (a *Analyzer) redactsMemos() bool {
+	return a.RedactMemos || a.MemoRedactor != nil
+}
+
+// isPrintable returns whether `s` is valid UTF-8 made of printable
+// characters only.
+func isPrintable(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// forumContentLength is how much of a forum post's content gets
+// printed.
+const forumContentLength = 200
+
+// countdown describes the time `left` until an expiration, as `in
+// Xh Ym Zs`, or `expired Xs ago` once past it.
+func countdown(left time.Duration) string {
+	if left < 0 {
+		return fmt.Sprintf("expired %s ago", formatDuration(-left))
+	}
+	return "in " + formatDuration(left)
+}
+
+// formatDuration formats `d` as `Xh Ym Zs`, leaving out the leading
+// zero units and anything below the second.
+func formatDuration(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	hours, minutes, seconds := d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second
+	switch {
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm %ds", hours, minutes, seconds)
+	case minutes > 0:
+		return fmt.Sprintf("%dm %ds", minutes, seconds)
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
+
+// formatTime renders `t` according to TimeFormat and TimeLocation.
+func (a *Analyzer) formatTime(t time.Time) string {
+	if a.TimeLocation != nil {
+		t = t.In(a.TimeLocation)
+	}
+	if a.TimeFormat != "" {
+		return t.Format(a.TimeFormat)
+	}
+	return t.String()
+}
+
+// truncate cuts `s` down to `max` bytes, marking the cut with an
+// ellipsis.
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max] + "..."
+}
+
+// wasmMagic is the header starting every WebAssembly binary module.
+var wasmMagic = []byte("\x00asm")
+
+func codeFormat(code []byte) string {
+	if len(code) >= 8 && bytes.HasPrefix(code, wasmMagic) {
+		return fmt.Sprintf("WASM (version %d)", binary.LittleEndian.Uint32(code[4:8]))
+	}
+	return "unknown"
+}
+
+// checkDuplicateActions notes actions which are byte-for-byte
+// identical to a previous one, which is most likely a mistake.
+func (a *Analyzer) checkDuplicateActions(actions []*eos.Action) {
+	seen := map[string]int{}
+	for idx, act := range actions {
+		data, err := eos.MarshalBinary(act)
+		if err != nil {
+			continue
+		}
+
+		if first, found := seen[string(data)]; found {
+			a.note("actions %d and %d are identical", first+1, idx+1)
+			continue
+		}
+		seen[string(data)] = idx
+	}
+}
+
+// printAuthority prints the threshold of `auth`, along with every
+// key, account and wait weighing into it.
+func (a *Analyzer) printAuthority(label string, auth eos.Authority) {
+	a.Pf("%s authority, threshold %d:\n", label, auth.Threshold)
+	defer a.nest()()
+	for _, key := range auth.Keys {
+		a.Pf("key %s, weight %d\n", key.PublicKey, key.Weight)
+	}
+	for _, account := range auth.Accounts {
+		a.Pf("account %s@%s, weight %d\n", account.Permission.Actor, account.Permission.Permission, account.Weight)
+	}
+	for _, wait := range auth.Waits {
+		a.Pf("wait %d seconds, weight %d\n", wait.WaitSec, wait.Weight)
+	}
+}
+
+// nest indents the output by one more level of Indent, until the
+// returned function is called.
+func (a *Analyzer) nest() func() {
+	previous := a.indent
+	a.indent += a.Indent
+	return func() { a.indent = previous }
+}
+
+// analyzeNested analyzes a transaction embedded in an action (like
+// an msig proposal), indenting its output under the current one.
+func (a *Analyzer) analyzeNested(tx *eos.Transaction) error {
+	if a.MaxDepth > 0 && a.depth >= a.MaxDepth {
+		a.Pf("... (max depth reached, %d levels not expanded)\n", nestingDepth(tx))
+		return nil
+	}
+
+	defer a.nest()()
+	a.depth++
+	defer func() { a.depth-- }()
+
+	return a.AnalyzeTransaction(tx)
+}
+
+// nestingDepth returns how many levels of transactions `tx` holds,
+// counting itself.
+func nestingDepth(tx *eos.Transaction) int {
+	deepest := 0
+	for _, act := range tx.Actions {
+		var nested *eos.Transaction
+		switch obj := actionData(act).(type) {
+		case *msig.Propose:
+			nested = obj.Transaction
+		case *WrapExec:
+			nested = obj.Transaction
+		}
+		if nested != nil {
+			if depth := nestingDepth(nested); depth > deepest {
+				deepest = depth
+			}
+		}
+	}
+	return deepest + 1
+}
+
+// actionData returns the decoded data of `act`. Some packages (like
+// `msig`) register pointer types, in which case the decoder hands
+// back a pointer to a pointer, which we flatten here.
+//
+// Actions built before packing may rather hold their data as a
+// struct value, or as a JSON object. These are turned into a pointer
+// to their registered type, for the decoders to pick up.
+func actionData(act *eos.Action) interface{} {
+	if m, ok := act.ActionData.Data.(map[string]interface{}); ok {
+		return jsonActionData(act, m)
+	}
+
+	v := reflect.ValueOf(act.ActionData.Data)
+	switch {
+	case v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Ptr:
+		return v.Elem().Interface()
+	case v.Kind() == reflect.Struct:
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		return ptr.Interface()
+	}
+	return act.ActionData.Data
+}
+
+// jsonActionData decodes the JSON object `m` into the type registered
+// for `act`, leaving it as is when there's none or it doesn't fit.
+func jsonActionData(act *eos.Action, m map[string]interface{}) interface{} {
+	objType := eos.RegisteredActions[act.Account][act.Name]
+	if objType == nil {
+		return m
+	}
+
+	cnt, err := json.Marshal(m)
+	if err != nil {
+		return m
+	}
+	obj := reflect.New(objType)
+	if err := json.Unmarshal(cnt, obj.Interface()); err != nil {
+		return m
+	}
+
+	if objType.Kind() == reflect.Ptr {
+		return obj.Elem().Interface()
+	}
+	return obj.Interface()
+}
+
+// labeled returns `account` followed by its label in AccountLabels,
+// if it has one.
+func (a *Analyzer) labeled(account eos.AccountName) string {
+	if label, ok := a.AccountLabels[account]; ok {
+		return fmt.Sprintf("%s (%s)", account, label)
+	}
+	return string(account)
+}
+
+func joinAccountNames(accounts []eos.AccountName) string {
+	names := make([]string, len(accounts))
+	for idx, acct := range accounts {
+		names[idx] = string(acct)
+	}
+	return strings.Join(names, ", ")
+}
+
+func permissionLevelStrings(levels []eos.PermissionLevel) []string {
+	out := []string{}
+	for _, level := range levels {
+		out = append(out, fmt.Sprintf("%s@%s", level.Actor, level.Permission))
+	}
+	return out
+}
+
+// lineEndings turns every line ending into `\n`.
+var lineEndings = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+// write sends `s` to the Writer, prefixing each non-empty line with
+// the current indentation. Line endings are normalized to `\n`,
+// whatever the data being printed, so output diffs the same across
+// platforms.
+func (a *Analyzer) write(s string) {
+	s = lineEndings.Replace(s)
+	if a.indent == "" {
+		a.output(s)
+		return
+	}
+
+	for len(s) > 0 {
+		if !a.midLine && s[0] != '\n' {
+			a.output(a.indent)
+		}
+
+		idx := strings.IndexByte(s, '\n')
+		if idx == -1 {
+			a.output(s)
+			a.midLine = true
+			return
+		}
+
+		a.output(s[:idx+1])
+		a.midLine = false
+		s = s[idx+1:]
+	}
+}
+
+// output writes `s` to the Writer, up to MaxOutputBytes in total,
+// past which the output is cut and a truncation line is appended.
+func (a *Analyzer) output(s string) {
+	if a.MaxOutputBytes <= 0 {
+		_, _ = io.WriteString(a.Writer, s)
+		return
+	}
+	if a.truncated {
+		return
+	}
+
+	if left := a.MaxOutputBytes - a.written; len(s) > left {
+		s = s[:left]
+		if s != "" && !strings.HasSuffix(s, "\n") {
+			s += "\n"
+		}
+		s += fmt.Sprintf("... (output truncated at %d bytes)\n", a.MaxOutputBytes)
+		a.truncated = true
+	}
+	a.written += len(s)
+	_, _ = io.WriteString(a.Writer, s)
+}
+
+const bannerWidth = 69
+
+const (
+	colorReset    = "\x1b[0m"
+	colorSection  = "\x1b[1;36m"
+	colorWarning  = "\x1b[1;33m"
+	colorCritical = "\x1b[1;31m"
+	colorAction   = "\x1b[1m"
+)
+
+// colorize wraps `s` in the given ANSI `color`, when Color is
+// enabled.
+func (a *Analyzer) colorize(color, s string) string {
+	if !a.Color {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// section prints the banner introducing a new section of the
+// analysis.
+func (a *Analyzer) section(title string) {
+	if a.NoBanners {
+		a.Pln()
+		a.Pln(a.colorize(colorSection, "== "+title+" =="))
+		a.Pln()
+		return
+	}
+
+	dashes := strings.Repeat("-", bannerWidth)
+	title = " " + title + " "
+	left := (bannerWidth - len(title)) / 2
+	right := bannerWidth - len(title) - left
+
+	a.Pln()
+	a.Pln(a.colorize(colorSection, dashes))
+	a.Pln(a.colorize(colorSection, dashes[:left]+title+dashes[:right]))
+	a.Pln(a.colorize(colorSection, dashes))
+	a.Pln()
+}
+
+// warn prints a warning line, meant to catch the reviewer's eye.
+func (a *Analyzer) warn(format string, v ...interface{}) {
+	line := "WARNING: " + fmt.Sprintf(format, v...)
+	a.Warnings = append(a.Warnings, line)
+	a.Pln(a.colorize(colorWarning, line))
+}
+
+// critical prints a line flagging something that must not be
+// overlooked during review.
+func (a *Analyzer) critical(format string, v ...interface{}) {
+	line := "CRITICAL: " + fmt.Sprintf(format, v...)
+	a.Warnings = append(a.Warnings, line)
+	a.Pln(a.colorize(colorCritical, line))
+}
+
+// note prints a line pointing out something unusual, though not
+// necessarily wrong.
+func (a *Analyzer) note(format string, v ...interface{}) {
+	a.Pln("NOTE: " + fmt.Sprintf(format, v...))
+}
+
 // Pln is a short for Println on the Writer
 func (a *Analyzer) Pln(v ...interface{}) {
-	fmt.Fprintln(a.Writer, v...)
+	a.write(fmt.Sprintln(v...))
 }
 
 // VerbPln is a short for Println on the Writer, in Verbose mode.
 func (a *Analyzer) VerbPln(v ...interface{}) {
 	if a.Verbose {
-		fmt.Fprintln(a.Writer, v...)
+		a.write(fmt.Sprintln(v...))
 	}
 }
 
 // VerbDump is a short for spew.Fdump on the Writer, in Verbose mode.
 func (a *Analyzer) VerbDump(v ...interface{}) {
 	if a.Verbose {
-		spew.Fdump(a.Writer, v...)
+		a.dump(v...)
 	}
 }
 
 // Dump is a short for spew.Fdump on the Writer.
 func (a *Analyzer) Dump(v ...interface{}) {
-	spew.Fdump(a.Writer, v...)
+	a.dump(v...)
+}
+
+// dump spews each of `v`, truncating byte blobs to MaxDumpBytes, and
+// hex dumping them in HexDump mode.
+func (a *Analyzer) dump(v ...interface{}) {
+	for _, obj := range v {
+		var blob []byte
+		isBlob := true
+		switch data := obj.(type) {
+		case []byte:
+			blob = data
+		case eos.HexBytes:
+			blob = data
+		default:
+			isBlob = false
+		}
+
+		truncated := 0
+		if isBlob && a.MaxDumpBytes > 0 && len(blob) > a.MaxDumpBytes {
+			blob, truncated = blob[:a.MaxDumpBytes], len(blob)-a.MaxDumpBytes
+			if _, ok := obj.(eos.HexBytes); ok {
+				obj = eos.HexBytes(blob)
+			} else {
+				obj = blob
+			}
+		}
+
+		if isBlob && a.HexDump {
+			a.write(hex.Dump(blob))
+		} else {
+			a.write(spew.Sdump(obj))
+		}
+		if truncated > 0 {
+			a.Pf("... (%d more bytes truncated)\n", truncated)
+		}
+	}
+}
+
+// rawSuffix returns the ` (raw: <hex>)` suffix of the line of `act`
+// in ShowRawData mode, and an empty string otherwise. Actions built
+// from their decoded data get it packed.
+func (a *Analyzer) rawSuffix(act *eos.Action) string {
+	if !a.ShowRawData {
+		return ""
+	}
+	if decoded := actionData(act); a.redactsMemos() && (decoded == nil || hasMemo(decoded)) {
+		return ""
+	}
+
+	data := []byte(act.HexData)
+	if len(data) == 0 && act.Data != nil {
+		packed, err := eos.MarshalBinary(act.Data)
+		if err != nil {
+			return ""
+		}
+		data = packed
+	}
+	if len(data) == 0 {
+		return ""
+	}
+
+	suffix := ""
+	if a.MaxDumpBytes > 0 && len(data) > a.MaxDumpBytes {
+		data, suffix = data[:a.MaxDumpBytes], "..."
+	}
+	return fmt.Sprintf(" (raw: %s%s)", hex.EncodeToString(data), suffix)
+}
+
+// printDecodedData prints the decoded `data` of `act`, for the types
+// with no summary line, as JSON.
+func (a *Analyzer) printDecodedData(act *eos.Action, data interface{}) {
+	cnt, err := json.Marshal(data)
+	if err != nil {
+		a.Pf("Couldn't serialize decoded data into JSON: %s\n", err)
+		return
+	}
+	a.Pf("Data: %s%s\n", string(cnt), a.rawSuffix(act))
+}
+
+// printRawData prints the hex of undecoded action data, truncated to
+// MaxDumpBytes.
+func (a *Analyzer) printRawData(data []byte) {
+	truncated := 0
+	if a.MaxDumpBytes > 0 && len(data) > a.MaxDumpBytes {
+		data, truncated = data[:a.MaxDumpBytes], len(data)-a.MaxDumpBytes
+	}
+
+	a.Pf("Raw action data (undecoded): %s\n", hex.EncodeToString(data))
+	if truncated > 0 {
+		a.Pf("... (%d more bytes truncated)\n", truncated)
+	}
 }
 
 // Pf is a short for Println on the Writer
 func (a *Analyzer) Pf(format string, v ...interface{}) {
-	fmt.Fprintf(a.Writer, format, v...)
+	a.write(fmt.Sprintf(format, v...))
 }
 
 // VerbPf is a short for Println on the Writer, in Verbose mode.
 func (a *Analyzer) VerbPf(format string, v ...interface{}) {
 	if a.Verbose {
-		fmt.Fprintf(a.Writer, format, v...)
+		a.write(fmt.Sprintf(format, v...))
 	}
 }
@@ -0,0 +1,1016 @@
+package analysis
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/forum"
+	"github.com/eoscanada/eos-go/msig"
+	"github.com/eoscanada/eos-go/system"
+	"github.com/eoscanada/eos-go/token"
+)
+
+func TestAnalyzePackedVerboseDumpsBothPayloads(t *testing.T) {
+	trx := testPack(t, testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "payload")))
+	cfd, err := eos.MarshalBinary([]eos.HexBytes{{0xca, 0xfe, 0xba, 0xbe}})
+	if err != nil {
+		t.Fatalf("packing context-free data: %s", err)
+	}
+	trx.PackedContextFreeData = cfd
+
+	a := NewAnalyzer(true)
+	if err := a.AnalyzePacked(trx); err != nil {
+		t.Fatalf("AnalyzePacked: %s", err)
+	}
+
+	out := a.String()
+	assertContains(t, out, spew.Sdump(trx.PackedContextFreeData))
+	assertContains(t, out, spew.Sdump(trx.PackedTransaction))
+}
+
+func TestAnalyzeTransfer(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(
+		testTransfer(t, "alice", "bob", "1.5000 EOS", "for lunch"),
+		testTransfer(t, "bob", "carol", "0.0001 EOS", ""),
+	))
+
+	assertContains(t, out, `Transfer 1.5000 EOS from alice to bob, memo: "for lunch"`)
+	assertContains(t, out, `Transfer 0.0001 EOS from bob to carol, memo: ""`)
+}
+
+func TestAnalyzeDelegateBW(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(
+		system.NewDelegateBW("alice", "bob", testAsset(t, "2.0000 EOS"), testAsset(t, "3.0000 EOS"), true),
+		system.NewUndelegateBW("alice", "bob", testAsset(t, "0.5000 EOS"), testAsset(t, "0.2500 EOS")),
+	))
+
+	assertContains(t, out, "Delegate 3.0000 EOS for NET and 2.0000 EOS for CPU from alice to bob")
+	assertContains(t, out, "Transfer ownership of the stake to receiver: true")
+	assertContains(t, out, "Undelegate 0.2500 EOS for NET and 0.5000 EOS for CPU staked by alice to bob")
+}
+
+func TestReset(t *testing.T) {
+	first := testPack(t, testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "first")))
+	second := testPack(t, testTransaction(testTransfer(t, "carol", "dave", "2.0000 EOS", "second")))
+
+	a := NewAnalyzer(false)
+	if err := a.AnalyzePacked(first); err != nil {
+		t.Fatalf("AnalyzePacked: %s", err)
+	}
+	assertContains(t, a.String(), ID(first))
+
+	a.Reset()
+	if err := a.AnalyzePacked(second); err != nil {
+		t.Fatalf("AnalyzePacked: %s", err)
+	}
+	out := a.String()
+	assertContains(t, out, ID(second))
+	assertNotContains(t, out, ID(first))
+}
+
+func TestAnalyzeExpiredTransaction(t *testing.T) {
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""))
+	tx.Expiration = eos.JSONTime{Time: time.Now().UTC().Add(-time.Hour - time.Minute)}
+
+	out := testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, "WARNING: transaction expired 1h")
+	assertNotContains(t, out, "WARNING: expires in under")
+}
+
+func TestAnalyzeNearExpiryTransaction(t *testing.T) {
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""))
+	tx.Expiration = eos.JSONTime{Time: time.Now().UTC().Add(10 * time.Second)}
+
+	out := testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, "WARNING: expires in under 30s")
+	assertNotContains(t, out, "WARNING: transaction expired")
+}
+
+func TestAnalyzeSetCodeFormat(t *testing.T) {
+	wasm := append([]byte{0x00, 'a', 's', 'm', 0x01, 0x00, 0x00, 0x00}, make([]byte, 2040)...)
+	tests := []struct {
+		name string
+		code []byte
+		want []string
+	}{
+		{"wasm", wasm, []string{"Code size: 2048 bytes (2.0 KB)", "Code format: WASM (version 1)"}},
+		{"empty", nil, []string{"Code: EMPTY (clearing contract)"}},
+		{"not wasm", []byte("(module)"), []string{"Code size: 8 bytes", "Code format: unknown"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := testAnalyze(t, NewAnalyzer(false), testTransaction(testSetCode("alice", test.code)))
+			for _, want := range test.want {
+				assertContains(t, out, want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeColor(t *testing.T) {
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""))
+
+	assertNotContains(t, testAnalyze(t, NewAnalyzer(false), tx), "\x1b[")
+
+	a := NewAnalyzer(false)
+	a.Color = true
+	out := testAnalyze(t, a, tx)
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "TRANSACTION HEADER") && !strings.Contains(line, "\x1b[") {
+			t.Errorf("header banner %q isn't colored", line)
+		}
+	}
+	assertContains(t, out, "\x1b[")
+}
+
+func TestAnalyzeNewAccount(t *testing.T) {
+	owner, active1, active2 := testKey(t).PublicKey(), testKey(t).PublicKey(), testKey(t).PublicKey()
+	act := system.NewCustomNewAccount("alice", "newaccount11",
+		eos.Authority{Threshold: 1, Keys: []eos.KeyWeight{{PublicKey: owner, Weight: 1}}},
+		eos.Authority{Threshold: 2, Keys: []eos.KeyWeight{{PublicKey: active1, Weight: 1}, {PublicKey: active2, Weight: 1}}},
+	)
+
+	// Not packed: `eos-go` formats the action data it decodes, which
+	// appends to the first key's content in place, over the second's.
+	a := NewAnalyzer(false)
+	if err := a.AnalyzeTransaction(testTransaction(act)); err != nil {
+		t.Fatalf("AnalyzeTransaction: %s", err)
+	}
+	out := a.String()
+	assertContains(t, out, "New account newaccount11, created by alice")
+	assertContains(t, out, "Owner authority, threshold 1:\n  key "+owner.String()+", weight 1\n")
+	assertContains(t, out, "Active authority, threshold 2:\n  key "+active1.String()+", weight 1\n  key "+active2.String()+", weight 1\n")
+}
+
+func TestAnalyzeUpdateAuth(t *testing.T) {
+	key := testKey(t).PublicKey()
+	act := system.NewUpdateAuth("alice", "active", "owner", eos.Authority{
+		Threshold: 2,
+		Keys:      []eos.KeyWeight{{PublicKey: key, Weight: 1}},
+		Accounts:  []eos.PermissionLevelWeight{{Permission: eos.PermissionLevel{Actor: "bob", Permission: "active"}, Weight: 1}},
+		Waits:     []eos.WaitWeight{{WaitSec: 3600, Weight: 1}},
+	}, "owner")
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(act))
+	assertContains(t, out, "Update permission alice@active, parent: owner\n")
+	assertContains(t, out, "New authority, threshold 2:\n  key "+key.String()+", weight 1\n  account bob@active, weight 1\n  wait 3600 seconds, weight 1\n")
+}
+
+func TestAnalyzeDeleteAuth(t *testing.T) {
+	act := testAction("eosio", "deleteauth", "alice", DeleteAuth{Account: "alice", Permission: "claim"})
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(act))
+	assertContains(t, out, "Delete permission alice@claim\n")
+}
+
+func TestAnalyzeNoAuthorization(t *testing.T) {
+	act := testTransfer(t, "alice", "bob", "1.0000 EOS", "")
+	act.Authorization = nil
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(act))
+	assertContains(t, out, "WARNING: action has no authorization")
+
+	out = testAnalyze(t, NewAnalyzer(false), testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "")))
+	assertNotContains(t, out, "no authorization")
+}
+
+func TestAnalyzeDuplicateActions(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", "rent"),
+		testTransfer(t, "alice", "carol", "1.0000 EOS", "rent"),
+		testTransfer(t, "alice", "bob", "1.0000 EOS", "rent"),
+	))
+	assertContains(t, out, "NOTE: actions 1 and 3 are identical\n")
+	assertNotContains(t, out, "actions 1 and 2 are identical")
+	assertNotContains(t, out, "actions 2 and 3 are identical")
+}
+
+func TestAnalyzeContextFreeWarning(t *testing.T) {
+	cfa := testRawAction("mycontract", "cfread", []byte{0x01})
+	cfa.Authorization = nil
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""))
+	tx.ContextFreeActions = []*eos.Action{cfa}
+
+	out := testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, "WARNING: transaction contains 1 context-free action(s)\nContext-free actions: 1\n")
+
+	out = testAnalyze(t, NewAnalyzer(false), testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "")))
+	assertNotContains(t, out, "context-free action(s)")
+}
+
+func TestAnalyzeBuyRAMBytes(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(system.NewBuyRAMBytes("alice", "bob", 8192)))
+	assertContains(t, out, "Buy 8192 bytes of RAM for bob, paid by alice\n")
+}
+
+func TestAnalyzeSellRAM(t *testing.T) {
+	act := testAction("eosio", "sellram", "alice", SellRAM{Account: "alice", Bytes: 4096})
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(act))
+	assertContains(t, out, "Sell 4096 bytes of RAM from alice\n")
+}
+
+func TestDumpMaxDumpBytes(t *testing.T) {
+	blob := bytes.Repeat([]byte{0xab}, 1000)
+
+	a := NewAnalyzer(true)
+	a.MaxDumpBytes = 100
+	a.Dump(blob)
+	out := a.String()
+	if n := strings.Count(out, "171"); n != 100 {
+		t.Errorf("got %d bytes dumped, want 100:\n%s", n, out)
+	}
+	assertContains(t, out, "... (900 more bytes truncated)\n")
+
+	a = NewAnalyzer(true)
+	a.Dump(blob)
+	out = a.String()
+	if n := strings.Count(out, "171"); n != 1000 {
+		t.Errorf("got %d bytes dumped, want 1000:\n%s", n, out)
+	}
+	assertNotContains(t, out, "truncated")
+}
+
+func TestDumpHexDump(t *testing.T) {
+	a := NewAnalyzer(true)
+	a.HexDump = true
+	out := testAnalyze(t, a, testTransaction(testSetCode("mycontract", testWasm)))
+	assertContains(t, out, "00000000  00 61 73 6d 01 00 00 00  ")
+	assertContains(t, out, "|.asm....|\n")
+
+	a = NewAnalyzer(true)
+	a.HexDump = true
+	a.MaxDumpBytes = 16
+	a.Dump(bytes.Repeat([]byte{0xab}, 40))
+	out = a.String()
+	assertContains(t, out, "00000000  ab ab ab ab")
+	assertNotContains(t, out, "00000010")
+	assertContains(t, out, "... (24 more bytes truncated)\n")
+}
+
+func TestAnalyzeLinkAuth(t *testing.T) {
+	link := testAction("eosio", "linkauth", "alice", LinkAuth{Account: "alice", Code: "eosio.token", Type: "transfer", Requirement: "spender"})
+	unlink := testAction("eosio", "unlinkauth", "alice", UnlinkAuth{Account: "alice", Code: "eosio.token", Type: "issue"})
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(link, unlink))
+	assertContains(t, out, "linkauth: alice links eosio.token::transfer to permission 'spender'\n")
+	assertContains(t, out, "unlinkauth: alice unlinks eosio.token::issue from its required permission\n")
+}
+
+func TestAnalyzeCompact(t *testing.T) {
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", "hi"),
+		testRawAction("mycontract", "doit", []byte{0x01}),
+	)
+
+	a := NewAnalyzer(false)
+	a.Compact = true
+	out := testAnalyze(t, a, tx)
+	assertNotContains(t, out, "---")
+	assertContains(t, out, "Transaction "+transactionID(tx)+", expiration: ")
+	assertContains(t, out, "\n1) eosio.token::transfer [alice@active] -> Transfer 1.0000 EOS from alice to bob, memo: \"hi\"\n")
+	assertContains(t, out, "\n2) mycontract::doit [mycontract@active]\n")
+}
+
+func TestAnalyzePrivilegedSetCode(t *testing.T) {
+	code := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(testSetCode("eosio", code)))
+	assertContains(t, out, "CRITICAL: deploying code to privileged system account eosio\n")
+
+	out = testAnalyze(t, NewAnalyzer(false), testTransaction(testSetCode("mycontract", code)))
+	assertNotContains(t, out, "CRITICAL")
+
+	a := NewAnalyzer(false)
+	a.PrivilegedAccounts = []eos.AccountName{"mycontract"}
+	out = testAnalyze(t, a, testTransaction(testSetCode("mycontract", code)))
+	assertContains(t, out, "CRITICAL: deploying code to privileged system account mycontract\n")
+}
+
+func TestAnalyzeAnchors(t *testing.T) {
+	cfa := testRawAction("mycontract", "cfread", []byte{0x01})
+	cfa.Authorization = nil
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", ""),
+		testTransfer(t, "bob", "carol", "1.0000 EOS", ""),
+	)
+	tx.ContextFreeActions = []*eos.Action{cfa}
+
+	a := NewAnalyzer(false)
+	a.Anchors = true
+	out := testAnalyze(t, a, tx)
+	assertContains(t, out, "[cfaction:0] 1. Action mycontract::cfread")
+	assertContains(t, out, "[action:0] 1. Action eosio.token::transfer")
+	assertContains(t, out, "[action:1] 2. Action eosio.token::transfer")
+
+	out = testAnalyze(t, NewAnalyzer(false), tx)
+	assertNotContains(t, out, "[action:")
+}
+
+func TestAnalyzeVoteProducer(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(system.NewVoteProducer("alice", "", "bp1", "bp2", "bp3")))
+	assertContains(t, out, "Vote: alice votes directly for 3 producer(s): bp1, bp2, bp3\n")
+
+	out = testAnalyze(t, NewAnalyzer(false), testTransaction(system.NewVoteProducer("alice", "proxy1")))
+	assertContains(t, out, "Vote: alice votes via proxy proxy1\n")
+}
+
+func TestAnalyzeRegProducer(t *testing.T) {
+	key := testKey(t).PublicKey()
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(system.NewRegProducer("bp1", key, "https://bp1.example.com")))
+	assertContains(t, out, "Register producer bp1\nProducer key: "+key.String()+"\nURL: https://bp1.example.com\nLocation: 0\n")
+}
+
+func TestAnalyzeRefund(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(system.NewRefund("alice")))
+	assertContains(t, out, "Refund unstaked tokens to alice\n")
+}
+
+func TestAnalyzeDelayedTransaction(t *testing.T) {
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""))
+	tx.DelaySec = 3600
+
+	out := testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, "WARNING: delayed transaction, executes after 3600s\n")
+
+	tx.DelaySec = 0
+	out = testAnalyze(t, NewAnalyzer(false), tx)
+	assertNotContains(t, out, "delayed transaction")
+}
+
+func TestAnalyzeForumVote(t *testing.T) {
+	act := forum.NewVote("alice", "proposal1", "abcdef", "yes")
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(act))
+	assertContains(t, out, "Forum vote by alice on proposition \"proposal1\": \"yes\"\nProposition hash: abcdef\n")
+}
+
+func TestAnalyzeForumPost(t *testing.T) {
+	content := strings.Repeat("a", forumContentLength+10)
+	post := forum.NewPost("alice", "uuid1", "Hello", content, "bob", "uuid0", false, "")
+	unpost := testAction("eosforumtest", "unpost", "alice", Unpost{Poster: "alice", PostUUID: "uuid1"})
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(post, unpost))
+	assertContains(t, out, "Forum post \"Hello\" by alice\nPost UUID: uuid1\nIn reply to: bob's post uuid0\n")
+	assertContains(t, out, "Content: \""+strings.Repeat("a", forumContentLength)+"...\"\n")
+	assertContains(t, out, "Remove forum post uuid1 by alice\n")
+}
+
+func TestAnalyzeForumPropose(t *testing.T) {
+	act := testAction("eosforumtest", "propose", "alice", ForumPropose{
+		Proposer:     "alice",
+		ProposalName: "prop1",
+		Title:        "Raise the RAM",
+		ProposalJSON: `{"type":"referendum"}`,
+		ExpiresAt:    eos.JSONTime{Time: time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)},
+	})
+
+	a := NewAnalyzer(false)
+	a.TimeFormat = time.RFC3339
+	out := testAnalyze(t, a, testTransaction(act))
+	assertContains(t, out, "Forum proposal \"Raise the RAM\" (prop1) by alice\nExpires at: 2018-07-01T00:00:00Z\nProposal metadata: {\"type\":\"referendum\"}\n")
+}
+
+func TestAnalyzeStrict(t *testing.T) {
+	trx := testPack(t, testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", ""),
+		testRawAction("mycontract", "doit", []byte{0x01}),
+	))
+
+	a := NewAnalyzer(false)
+	a.Strict = true
+	err := a.AnalyzePacked(trx)
+	if err == nil || !strings.Contains(err.Error(), "mycontract::doit") {
+		t.Errorf("got error %v, want one naming mycontract::doit", err)
+	}
+
+	if err := NewAnalyzer(false).AnalyzePacked(trx); err != nil {
+		t.Errorf("AnalyzePacked without Strict: %s", err)
+	}
+
+	// Decoded data with no summary line isn't an error.
+	a = NewAnalyzer(false)
+	a.Strict = true
+	out := testAnalyze(t, a, testTransaction(token.NewCreate("eosio", testAsset(t, "1000000.0000 EOS"))))
+	assertContains(t, out, `Data: {"issuer":"eosio",`)
+}
+
+func TestAnalyzeBinaryMemo(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "ab\x00cd")))
+	assertContains(t, out, "Memo (hex): 6162006364\n")
+
+	out = testAnalyze(t, NewAnalyzer(false), testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "abcd")))
+	assertNotContains(t, out, "Memo (hex)")
+}
+
+func TestAnalyzeMaxDepth(t *testing.T) {
+	// Four proposals, each proposing the next, around a transfer.
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "innermost"))
+	for idx := 0; idx < 4; idx++ {
+		tx = testTransaction(msig.NewPropose("alice", "prop", []eos.PermissionLevel{{Actor: "bob", Permission: "active"}}, tx))
+	}
+
+	a := NewAnalyzer(false)
+	a.MaxDepth = 2
+	out := testAnalyze(t, a, tx)
+	// The outer proposal, and those of the two levels expanded.
+	if n := strings.Count(out, `Proposal "prop" by alice`); n != 3 {
+		t.Errorf("got %d proposals printed, want 3", n)
+	}
+	assertContains(t, out, "... (max depth reached, 2 levels not expanded)\n")
+	assertNotContains(t, out, "innermost")
+
+	out = testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, "... (max depth reached, 1 levels not expanded)\n")
+
+	a = NewAnalyzer(false)
+	a.MaxDepth = 0
+	out = testAnalyze(t, a, tx)
+	assertNotContains(t, out, "max depth reached")
+	assertContains(t, out, `memo: "innermost"`)
+}
+
+func TestAnalyzeSetALimits(t *testing.T) {
+	unlimited := uint64(1<<64 - 1) // -1 on chain
+	act := testAction("eosio", "setalimits", "eosio", SetALimits{Account: "alice", RAMBytes: 8192, NetWeight: 100, CPUWeight: unlimited})
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(act))
+	assertContains(t, out, "Set resource limits of alice\nRAM bytes: 8192\nNET weight: 100\nCPU weight: unlimited\n")
+}
+
+func TestAnalyzeSetPriv(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(system.NewSetPriv("alice")))
+	assertContains(t, out, "Set privileged status of alice to true\n")
+}
+
+func TestAnalyzeWarnings(t *testing.T) {
+	act := testTransfer(t, "alice", "bob", "1.0000 EOS", "")
+	act.Authorization = nil
+	tx := testTransaction(act)
+	tx.Expiration.Time = time.Now().UTC().Add(-time.Hour - time.Minute).Truncate(time.Second)
+
+	a := NewAnalyzer(false)
+	testAnalyze(t, a, tx)
+	if len(a.Warnings) != 2 {
+		t.Fatalf("got warnings %q, want 2", a.Warnings)
+	}
+	if !strings.HasPrefix(a.Warnings[0], "WARNING: transaction expired 1h") {
+		t.Errorf("got first warning %q, want the expiration one", a.Warnings[0])
+	}
+	if a.Warnings[1] != "WARNING: action has no authorization" {
+		t.Errorf("got second warning %q, want the authorization one", a.Warnings[1])
+	}
+
+	a.Reset()
+	if len(a.Warnings) != 0 {
+		t.Errorf("got warnings %q after Reset, want none", a.Warnings)
+	}
+}
+
+func TestAnalyzeVoteProducerModes(t *testing.T) {
+	// A proxy takes precedence over any producers listed along.
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(system.NewVoteProducer("alice", "proxy1", "bp1")))
+	assertContains(t, out, "Vote: alice votes via proxy proxy1\n")
+	assertNotContains(t, out, "directly")
+
+	out = testAnalyze(t, NewAnalyzer(false), testTransaction(system.NewVoteProducer("alice", "", "bp1")))
+	assertContains(t, out, "Vote: alice votes directly for 1 producer(s): bp1\n")
+	assertNotContains(t, out, "via proxy")
+}
+
+func TestAnalyzeAccumulateErrors(t *testing.T) {
+	broken := testRawAction("eosio.token", "transfer", []byte{0x01, 0x02})
+	trx := testPack(t, testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "fine"), broken))
+
+	a := NewAnalyzer(false)
+	a.AccumulateErrors = true
+	if err := a.AnalyzePacked(trx); err != nil {
+		t.Fatalf("AnalyzePacked: %s", err)
+	}
+	out := a.String()
+	assertContains(t, out, `Transfer 1.0000 EOS from alice to bob, memo: "fine"`)
+	assertContains(t, out, "error decoding action 2: ")
+	if len(a.Errors) != 1 {
+		t.Errorf("got errors %v, want 1", a.Errors)
+	}
+
+	if err := NewAnalyzer(false).AnalyzePacked(trx); err == nil {
+		t.Error("AnalyzePacked succeeded on a broken action without AccumulateErrors")
+	}
+}
+
+func TestAnalyzePrimarySigner(t *testing.T) {
+	first := testTransfer(t, "carol", "bob", "1.0000 EOS", "")
+	first.Authorization = []eos.PermissionLevel{{Actor: "carol", Permission: "owner"}, {Actor: "alice", Permission: "active"}}
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(first, testTransfer(t, "alice", "bob", "1.0000 EOS", "")))
+	assertContains(t, out, "Primary signer (heuristic): carol@owner\n")
+}
+
+func TestAnalyzeMsigCancel(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(msig.NewCancel("alice", "upgrade1", "alice")))
+	assertContains(t, out, "Cancel proposal \"upgrade1\" by alice, canceled by: alice\n")
+}
+
+func TestAnalyzeMsigInvalidate(t *testing.T) {
+	act := testAction("eosio.msig", "invalidate", "alice", Invalidate{Account: "alice"})
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(act))
+	assertContains(t, out, "Invalidate all approvals given by alice\n")
+}
+
+func TestAnalyzeNestedIndent(t *testing.T) {
+	proposed := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""))
+	tx := testTransaction(msig.NewPropose("alice", "prop", []eos.PermissionLevel{{Actor: "bob", Permission: "active"}}, proposed))
+
+	out := testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, "\n1. Action eosio.msig::propose")
+	assertContains(t, out, "\n  1. Action eosio.token::transfer")
+
+	a := NewAnalyzer(false)
+	a.Indent = "\t"
+	out = testAnalyze(t, a, tx)
+	assertContains(t, out, "\n\t1. Action eosio.token::transfer")
+}
+
+func TestPrintID(t *testing.T) {
+	trx := testPack(t, testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "")))
+
+	want := hex.EncodeToString(trx.ID())
+	if got := ID(trx); got != want {
+		t.Errorf("got ID %q, want %q", got, want)
+	}
+
+	a := NewAnalyzer(false)
+	a.PrintID(trx)
+	if out := a.String(); out != "Transaction ID: "+want+"\n" {
+		t.Errorf("got %q, want only the ID", out)
+	}
+}
+
+func TestAnalyzeZlibPacked(t *testing.T) {
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "compressed"))
+	trx := testPack(t, tx)
+
+	// `eos-go` can't compress, so this is done by hand.
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	if _, err := w.Write(trx.PackedTransaction); err != nil {
+		t.Fatalf("compressing: %s", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("compressing: %s", err)
+	}
+	trx.PackedTransaction = buf.Bytes()
+	trx.Compression = eos.CompressionZlib
+
+	a := NewAnalyzer(false)
+	if err := a.AnalyzePacked(trx); err != nil {
+		t.Fatalf("AnalyzePacked: %s", err)
+	}
+	out := a.String()
+	assertContains(t, out, "Compression: zlib\n")
+	assertContains(t, out, transactionID(tx))
+	assertContains(t, out, `Transfer 1.0000 EOS from alice to bob, memo: "compressed"`)
+
+	out = testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, "Compression: none\n")
+}
+
+func TestAnalyzeActionFilter(t *testing.T) {
+	tx := testTransaction(
+		system.NewBuyRAMBytes("alice", "alice", 8192),
+		testTransfer(t, "alice", "bob", "1.0000 EOS", "kept"),
+		system.NewVoteProducer("alice", "", "bp1"),
+	)
+
+	a := NewAnalyzer(false)
+	a.ActionFilter = func(act *eos.Action) bool {
+		return act.Account == "eosio.token" && act.Name == "transfer"
+	}
+	out := testAnalyze(t, a, tx)
+	assertContains(t, out, "Actions: 3\n2. Action eosio.token::transfer")
+	assertContains(t, out, `memo: "kept"`)
+	assertNotContains(t, out, "eosio::buyrambytes")
+	assertNotContains(t, out, "eosio::voteproducer")
+	assertContains(t, out, "Actions skipped by the filter: 2\n")
+}
+
+func TestAnalyzeBidname(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(system.NewBidname("alice", "shortname", testAsset(t, "12.5000 EOS"))))
+	assertContains(t, out, "Bid 12.5000 EOS by alice on the name shortname\n")
+}
+
+func TestAnalyzeResourceRentals(t *testing.T) {
+	powerUp := testAction("eosio", "powerup", "alice", PowerUp{
+		Payer:      "alice",
+		Receiver:   "bob",
+		Days:       1,
+		NetFrac:    powerUpFracMax / 100,
+		CPUFrac:    powerUpFracMax / 4,
+		MaxPayment: testAsset(t, "5.0000 EOS"),
+	})
+	rentCPU := testAction("eosio", "rentcpu", "alice", RentCPU{From: "alice", Receiver: "bob", LoanPayment: testAsset(t, "1.0000 EOS"), LoanFund: testAsset(t, "0.5000 EOS")})
+	rentNet := testAction("eosio", "rentnet", "alice", RentNet{From: "alice", Receiver: "carol", LoanPayment: testAsset(t, "2.0000 EOS"), LoanFund: testAsset(t, "0.0000 EOS")})
+
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(powerUp, rentCPU, rentNet))
+	assertContains(t, out, "Power up bob for 1 days with 1.0000% of NET and 25.0000% of CPU, paid by alice (up to 5.0000 EOS)\n")
+	assertContains(t, out, "Rent CPU for bob, paying 1.0000 EOS from alice (0.5000 EOS added to the loan fund)\n")
+	assertContains(t, out, "Rent NET for carol, paying 2.0000 EOS from alice (0.0000 EOS added to the loan fund)\n")
+}
+
+func TestAnalyzeAccountLabels(t *testing.T) {
+	a := NewAnalyzer(false)
+	a.AccountLabels = map[eos.AccountName]string{
+		"eosio.ramfee": "RAM fee pool",
+		"eosio.token":  "system token",
+	}
+	out := testAnalyze(t, a, testTransaction(testTransfer(t, "alice", "eosio.ramfee", "1.0000 EOS", "")))
+	assertContains(t, out, "Action eosio.token::transfer (system token), authorized by: alice@active\n")
+	assertContains(t, out, "Transfer 1.0000 EOS from alice to eosio.ramfee (RAM fee pool), memo: ")
+}
+
+func TestAnalyzeKnownCodeHashes(t *testing.T) {
+	code := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	sum := sha256.Sum256(code)
+	tx := testTransaction(testSetCode("mycontract", code))
+
+	a := NewAnalyzer(false)
+	a.KnownCodeHashes = map[string]string{hex.EncodeToString(sum[:]): "mycontract v1.2 (audited)"}
+	out := testAnalyze(t, a, tx)
+	assertContains(t, out, "Code matches known build: mycontract v1.2 (audited)\n")
+	assertNotContains(t, out, "NOT in known set")
+
+	a = NewAnalyzer(false)
+	a.KnownCodeHashes = map[string]string{"00": "something else"}
+	out = testAnalyze(t, a, tx)
+	assertContains(t, out, "Code hash NOT in known set\n")
+	assertNotContains(t, out, "matches known build")
+}
+
+func TestAnalyzeUndecodedData(t *testing.T) {
+	tx := testTransaction(testRawAction("mycontract", "doit", []byte{0xde, 0xad, 0xbe, 0xef}))
+
+	out := testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, "Raw action data (undecoded): deadbeef\n")
+
+	a := NewAnalyzer(false)
+	a.MaxDumpBytes = 2
+	out = testAnalyze(t, a, tx)
+	assertContains(t, out, "Raw action data (undecoded): dead\n... (2 more bytes truncated)\n")
+
+	// Decoded data with no summary line is shown as JSON instead.
+	out = testAnalyze(t, NewAnalyzer(false), testTransaction(token.NewCreate("eosio", testAsset(t, "1000000.0000 EOS"))))
+	assertContains(t, out, `Data: {"issuer":"eosio",`)
+	assertNotContains(t, out, "Raw action data")
+}
+
+func TestAnalyzeNoCarriageReturns(t *testing.T) {
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", "line one\r\nline two"),
+		testRawAction("mycontract", "doit", []byte("\r\n")),
+	)
+
+	for _, verbose := range []bool{false, true} {
+		out := testAnalyze(t, NewAnalyzer(verbose), tx)
+		if strings.Contains(out, "\r") {
+			t.Errorf("verbose %v: output contains a carriage return:\n%q", verbose, out)
+		}
+	}
+}
+
+func TestAnalyzeTokenIssue(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(token.NewIssue("alice", testAsset(t, "100.0000 EOS"), "airdrop")))
+	assertContains(t, out, "Issue 100.0000 EOS to alice, memo: \"airdrop\"\n")
+}
+
+func TestAnalyzeTokenLifecycle(t *testing.T) {
+	symbol := Symbol{4, 'E', 'O', 'S'}
+	tx := testTransaction(
+		testAction("eosio.token", "retire", "eosio", Retire{Quantity: testAsset(t, "5.0000 EOS"), Memo: "burn"}),
+		testAction("eosio.token", "open", "bob", Open{Owner: "alice", Symbol: symbol, RAMPayer: "bob"}),
+		testAction("eosio.token", "close", "alice", Close{Owner: "alice", Symbol: symbol}),
+	)
+
+	out := testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, "Retire 5.0000 EOS, memo: \"burn\"\n")
+	assertContains(t, out, "Open a 4,EOS balance for alice, RAM paid by bob\n")
+	assertContains(t, out, "Close the 4,EOS balance of alice\n")
+}
+
+func TestAnalyzeNoBanners(t *testing.T) {
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""))
+
+	a := NewAnalyzer(false)
+	a.NoBanners = true
+	out := testAnalyze(t, a, tx)
+	assertNotContains(t, out, "---")
+	assertContains(t, out, "\n== TRANSACTION HEADER ==\n")
+	assertContains(t, out, "\n== ACTIONS ==\n")
+
+	out = testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, strings.Repeat("-", bannerWidth)+"\n")
+	assertNotContains(t, out, "== ACTIONS ==")
+}
+
+func TestAnalyzeTimeLocation(t *testing.T) {
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""))
+	tx.Expiration.Time = time.Date(2030, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	a := NewAnalyzer(false)
+	a.TimeLocation = time.FixedZone("EST", -5*3600)
+	a.TimeFormat = "2006-01-02 15:04 MST"
+	out := testAnalyze(t, a, tx)
+	assertContains(t, out, "Expiration: 2030-06-01 07:00 EST (")
+
+	out = testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, "Expiration: 2030-06-01 12:00:00 +0000 UTC (")
+}
+
+func TestUnpack(t *testing.T) {
+	cfa := testRawAction("mycontract", "cfread", []byte{0x01})
+	cfa.Authorization = nil
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", ""),
+		testTransfer(t, "bob", "carol", "1.0000 EOS", ""),
+	)
+	tx.ContextFreeActions = []*eos.Action{cfa}
+	sTx := eos.NewSignedTransaction(tx)
+	sTx.ContextFreeData = []eos.HexBytes{{0xca, 0xfe}}
+	trx, err := sTx.Pack(eos.CompressionNone)
+	if err != nil {
+		t.Fatalf("packing transaction: %s", err)
+	}
+
+	unpacked, err := NewAnalyzer(false).Unpack(trx)
+	if err != nil {
+		t.Fatalf("Unpack: %s", err)
+	}
+	if len(unpacked.Actions) != 2 || len(unpacked.ContextFreeActions) != 1 {
+		t.Fatalf("got %d actions and %d context-free ones, want 2 and 1", len(unpacked.Actions), len(unpacked.ContextFreeActions))
+	}
+	if _, ok := unpacked.Actions[1].ActionData.Data.(*token.Transfer); !ok {
+		t.Errorf("got data %#v, want a *token.Transfer", unpacked.Actions[1].ActionData.Data)
+	}
+	if len(unpacked.ContextFreeData) != 1 || !bytes.Equal(unpacked.ContextFreeData[0], []byte{0xca, 0xfe}) {
+		t.Errorf("got context-free data %x, want [cafe]", unpacked.ContextFreeData)
+	}
+}
+
+func TestAnalyzeSections(t *testing.T) {
+	trx := testPack(t, testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "")))
+	chainID, _ := hex.DecodeString(testChainID)
+
+	a := NewAnalyzer(false)
+	a.Sections = SectionActions
+	a.Summary = true
+	if err := a.AnalyzePacked(trx); err != nil {
+		t.Fatalf("AnalyzePacked: %s", err)
+	}
+	if err := a.AnalyzeSignatures(trx, chainID); err != nil {
+		t.Fatalf("AnalyzeSignatures: %s", err)
+	}
+	out := a.String()
+	assertContains(t, out, "ACTIONS")
+	assertContains(t, out, "Transfer 1.0000 EOS from alice to bob")
+	assertNotContains(t, out, "TRANSACTION HEADER")
+	assertNotContains(t, out, "Expiration:")
+	assertNotContains(t, out, "SIGNATURES")
+	assertNotContains(t, out, "Summary:")
+}
+
+func TestAnalyzeClaimRewards(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(system.NewClaimRewards("bp1")))
+	assertContains(t, out, "Claim producer rewards for bp1\n")
+}
+
+func TestAnalyzeSelfTransfer(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(testTransfer(t, "alice", "alice", "1.0000 EOS", "marker")))
+	assertContains(t, out, "NOTE: self-transfer (from == to)\n")
+
+	out = testAnalyze(t, NewAnalyzer(false), testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "")))
+	assertNotContains(t, out, "self-transfer")
+}
+
+func TestAnalyzeCodeHasher(t *testing.T) {
+	sum := sha1.Sum(testWasm)
+	digest := hex.EncodeToString(sum[:])
+
+	a := NewAnalyzer(false)
+	a.CodeHasher = sha1.New
+	a.KnownCodeHashes = map[string]string{digest: "sha1 build"}
+	out := testAnalyze(t, a, testTransaction(testSetCode("mycontract", testWasm)))
+	assertContains(t, out, "Code's hash: "+digest+"\n")
+	assertContains(t, out, fmt.Sprintf("Code's CRC32: %08x\n", crc32.ChecksumIEEE(testWasm)))
+	assertContains(t, out, "Code matches known build: sha1 build\n")
+	assertNotContains(t, out, "SHA256")
+}
+
+func TestAnalyzeContextFreeDataMismatch(t *testing.T) {
+	cfa := testRawAction("mycontract", "cfread", []byte{0x01})
+	cfa.Authorization = nil
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""))
+	tx.ContextFreeActions = []*eos.Action{cfa}
+
+	out := testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, "WARNING: 1 context-free action(s) but 0 context-free data blob(s)\n")
+
+	sTx := eos.NewSignedTransaction(tx)
+	sTx.ContextFreeData = []eos.HexBytes{{0x01}}
+	trx, err := sTx.Pack(eos.CompressionNone)
+	if err != nil {
+		t.Fatalf("packing transaction: %s", err)
+	}
+	a := NewAnalyzer(false)
+	if err := a.AnalyzePacked(trx); err != nil {
+		t.Fatalf("AnalyzePacked: %s", err)
+	}
+	assertNotContains(t, a.String(), "context-free data blob(s)")
+}
+
+func TestAnalyzeZeroTransfer(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(testTransfer(t, "alice", "bob", "0.0000 EOS", "spam")))
+	assertContains(t, out, "NOTE: zero-quantity transfer\n")
+
+	out = testAnalyze(t, NewAnalyzer(false), testTransaction(testTransfer(t, "alice", "bob", "0.0001 EOS", "")))
+	assertNotContains(t, out, "zero-quantity")
+}
+
+func TestAnalyzeShowRawData(t *testing.T) {
+	transfer := testTransfer(t, "alice", "bob", "1.0000 EOS", "raw")
+	raw, err := eos.MarshalBinary(transfer.Data)
+	if err != nil {
+		t.Fatalf("packing transfer: %s", err)
+	}
+	tx := testTransaction(transfer)
+
+	a := NewAnalyzer(false)
+	a.ShowRawData = true
+	out := testAnalyze(t, a, tx)
+	assertContains(t, out, `memo: "raw" (raw: `+hex.EncodeToString(raw)+")\n")
+
+	a = NewAnalyzer(false)
+	a.ShowRawData = true
+	a.MaxDumpBytes = 4
+	out = testAnalyze(t, a, tx)
+	assertContains(t, out, ` (raw: `+hex.EncodeToString(raw[:4])+"...)\n")
+
+	out = testAnalyze(t, NewAnalyzer(false), tx)
+	assertNotContains(t, out, "(raw: ")
+}
+
+func TestAnalyzeKnownAccounts(t *testing.T) {
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""), system.NewBuyRAMBytes("alice", "alice", 8192))
+	tx.Actions[0].Account = "eosoi.token"
+
+	a := NewAnalyzer(false)
+	a.KnownAccounts = map[eos.AccountName]bool{"eosio": true, "eosio.token": true}
+	out := testAnalyze(t, a, tx)
+	assertContains(t, out, "WARNING: contract eosoi.token isn't a known account, check for a typo\n")
+	assertNotContains(t, out, "contract eosio isn't a known account")
+
+	out = testAnalyze(t, NewAnalyzer(false), tx)
+	assertNotContains(t, out, "isn't a known account")
+}
+
+func TestCountdown(t *testing.T) {
+	tests := []struct {
+		left time.Duration
+		want string
+	}{
+		{2*time.Hour + 15*time.Minute, "in 2h 15m 0s"},
+		{90*time.Second + 500*time.Millisecond, "in 1m 30s"},
+		{42 * time.Second, "in 42s"},
+		{-42 * time.Second, "expired 42s ago"},
+		{-(3*time.Hour + 2*time.Second), "expired 3h 0m 2s ago"},
+	}
+	for _, test := range tests {
+		if got := countdown(test.left); got != test.want {
+			t.Errorf("countdown(%s) = %q, want %q", test.left, got, test.want)
+		}
+	}
+}
+
+func TestAnalyzeExpirationCountdown(t *testing.T) {
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""))
+	tx.Expiration.Time = time.Now().UTC().Add(2*time.Hour + 15*time.Minute + 30*time.Second).Truncate(time.Second)
+	out := testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, " (in 2h 15m ")
+
+	tx.Expiration.Time = time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	out = testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, " (expired 1h 0m ")
+	assertContains(t, out, "WARNING: transaction expired 1h 0m ")
+}
+
+func TestAnalyzeRegProxy(t *testing.T) {
+	out := testAnalyze(t, NewAnalyzer(false), testTransaction(system.NewRegProxy("myproxy", true)))
+	assertContains(t, out, "Register myproxy as a voting proxy\n")
+
+	out = testAnalyze(t, NewAnalyzer(false), testTransaction(system.NewRegProxy("myproxy", false)))
+	assertContains(t, out, "Unregister myproxy as a voting proxy\n")
+}
+
+func TestAnalyzeRedactMemos(t *testing.T) {
+	tx := testTransaction(
+		testTransfer(t, "alice", "exchange", "1.0000 EOS", "deposit 12345\x00"),
+		system.NewBuyRAMBytes("alice", "alice", 8192),
+	)
+
+	a := NewAnalyzer(true)
+	a.RedactMemos = true
+	a.ShowRawData = true
+	out := testAnalyze(t, a, tx)
+	assertContains(t, out, "memo: [redacted, 14 chars]\n")
+	assertContains(t, out, "(packed transaction dump left out, as memos are redacted)\n")
+	assertContains(t, out, "Buy 8192 bytes of RAM")
+	assertContains(t, out, " (raw: ")
+	assertNotContains(t, out, "12345")
+	assertNotContains(t, out, "Memo (hex)")
+	assertNotContains(t, out, hex.EncodeToString([]byte("deposit")))
+
+	a = NewAnalyzer(false)
+	a.MemoRedactor = func(memo string) string { return strings.Repeat("*", len(memo)) }
+	out = testAnalyze(t, a, tx)
+	assertContains(t, out, `memo: "**************"`)
+	assertNotContains(t, out, "12345")
+
+	out = testAnalyze(t, NewAnalyzer(false), tx)
+	assertContains(t, out, `memo: "deposit 12345\x00"`)
+}
+
+func TestAnalyzeRedactABIMemos(t *testing.T) {
+	data, err := eos.MarshalBinary(token.Transfer{From: "alice", To: "bob", Quantity: testAsset(t, "1.0000 TOK"), Memo: "secret"})
+	if err != nil {
+		t.Fatalf("packing transfer: %s", err)
+	}
+
+	a := NewAnalyzer(false)
+	a.RedactMemos = true
+	a.RegisterABI("mytoken", testTokenABI())
+	out := testAnalyze(t, a, testTransaction(testRawAction("mytoken", "transfer", data)))
+	assertContains(t, out, `"memo": "[redacted, 6 chars]"`)
+	assertNotContains(t, out, "secret")
+}
+
+func TestAnalyzeWrapExec(t *testing.T) {
+	wrapped := testTransaction(testTransfer(t, "eosio.ram", "alice", "100.0000 EOS", "recovered"))
+	exec := testAction("eosio.wrap", "exec", "eosio.wrap", WrapExec{Executer: "bp1", Transaction: wrapped})
+
+	a := NewAnalyzer(false)
+	out := testAnalyze(t, a, testTransaction(exec))
+	assertContains(t, out, "Execute a wrapped transaction, by bp1\n")
+	assertContains(t, out, "CRITICAL: eosio.wrap exec (privileged bypass)\n")
+	assertContains(t, out, ">>>>>>>>>>>>>>>>>>>>>>> WRAPPED TRANSACTION >>>>>>>>>>>>>>>>>>>>>>>>\n")
+	assertContains(t, out, `Transfer 100.0000 EOS from eosio.ram to alice, memo: "recovered"`)
+	assertContains(t, out, "<<<<<<<<<<<<<<<<<<<<<< END WRAPPED TRANSACTION <<<<<<<<<<<<<<<<<<<<<<\n")
+	if got := a.Classify(testTransaction(exec)); got != "privileged-exec" {
+		t.Errorf("got classification %q, want privileged-exec", got)
+	}
+
+	out = testAnalyze(t, NewAnalyzer(false), testTransaction(testSetCode("eosio.wrap", testWasm)))
+	assertContains(t, out, "CRITICAL: deploying code to privileged system account eosio.wrap\n")
+}
+
+func TestAnalyzeMaxOutputBytes(t *testing.T) {
+	var actions []*eos.Action
+	for i := 0; i < 50; i++ {
+		actions = append(actions, testTransfer(t, "alice", "bob", "1.0000 EOS", strings.Repeat("x", 100)))
+	}
+	tx := testTransaction(actions...)
+
+	a := NewAnalyzer(false)
+	a.MaxOutputBytes = 256
+	out := testAnalyze(t, a, tx)
+	trailer := "... (output truncated at 256 bytes)\n"
+	if !strings.HasSuffix(out, trailer) {
+		t.Errorf("output doesn't end with %q:\n%s", trailer, out)
+	}
+	if n := strings.Count(out, "truncated at"); n != 1 {
+		t.Errorf("got %d truncation lines, want 1:\n%s", n, out)
+	}
+	if len(out) > 256+1+len(trailer) {
+		t.Errorf("got %d bytes of output, want at most %d:\n%s", len(out), 256+1+len(trailer), out)
+	}
+
+	// The budget starts over after a reset.
+	a.Reset()
+	if again := testAnalyze(t, a, tx); again != out {
+		t.Errorf("got output after reset:\n%s\nwant:\n%s", again, out)
+	}
+
+	out = testAnalyze(t, NewAnalyzer(false), tx)
+	assertNotContains(t, out, "truncated at")
+}
@@ -0,0 +1,37 @@
+package analysis
+
+import (
+	"sort"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// AnalyzeAuthorizations prints every distinct `actor@permission`
+// authorizing the actions of `tx`, which is the set of permissions
+// that need to sign it.
+func (a *Analyzer) AnalyzeAuthorizations(tx *eos.Transaction) {
+	auths := uniqueAuthorizations(tx)
+
+	a.Pf("Distinct authorizations: %d\n", len(auths))
+	for _, auth := range auths {
+		a.Pf("- %s\n", auth)
+	}
+}
+
+func uniqueAuthorizations(tx *eos.Transaction) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, actions := range [][]*eos.Action{tx.ContextFreeActions, tx.Actions} {
+		for _, act := range actions {
+			for _, auth := range authorizationStrings(act) {
+				if !seen[auth] {
+					seen[auth] = true
+					out = append(out, auth)
+				}
+			}
+		}
+	}
+
+	sort.Strings(out)
+	return out
+}
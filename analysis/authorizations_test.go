@@ -0,0 +1,21 @@
+package analysis
+
+import (
+	"testing"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+func TestAnalyzeAuthorizations(t *testing.T) {
+	first := testTransfer(t, "carol", "bob", "1.0000 EOS", "")
+	first.Authorization = append(first.Authorization, eos.PermissionLevel{Actor: "alice", Permission: "active"})
+	second := testTransfer(t, "alice", "bob", "1.0000 EOS", "")
+	second.Authorization = append(second.Authorization, eos.PermissionLevel{Actor: "alice", Permission: "owner"})
+
+	a := NewAnalyzer(false)
+	a.AnalyzeAuthorizations(testTransaction(first, second))
+	want := "Distinct authorizations: 3\n- alice@active\n- alice@owner\n- carol@active\n"
+	if out := a.String(); out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
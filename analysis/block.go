@@ -0,0 +1,62 @@
+package analysis
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// AnalyzeBlock analyzes each transaction of `block` in turn, under a
+// `[trx N/M]` header. Deferred transactions are only referenced by ID
+// in a block, so only their ID and receipt get printed.
+func (a *Analyzer) AnalyzeBlock(block *eos.SignedBlock) error {
+	a.section("BLOCK")
+	a.Pf("Block number: %d\n", block.BlockNumber())
+	a.Pf("Producer: %s\n", block.Producer)
+	a.Pf("Timestamp: %s\n", a.formatTime(block.Timestamp.Time))
+	a.Pf("Transactions: %d\n", len(block.Transactions))
+
+	count := len(block.Transactions)
+	for idx, receipt := range block.Transactions {
+		a.Pln()
+		a.Pf("[trx %d/%d] ID: %s, status: %s, CPU usage: %dus, net usage words: %d\n", idx+1, count, hex.EncodeToString(receipt.Transaction.ID), receipt.Status, receipt.CPUUsageMicroSeconds, receipt.NetUsageWords)
+
+		if receipt.Transaction.Packed == nil {
+			a.Pln("Deferred transaction, only referenced by ID")
+			continue
+		}
+		if err := a.AnalyzePacked(receipt.Transaction.Packed); err != nil {
+			return fmt.Errorf("transaction %d/%d, %s", idx+1, count, err)
+		}
+	}
+
+	return nil
+}
+
+// AnalyzeBatch analyzes each of `trxs` in turn, under a `[trx N/M]`
+// header. Transactions that fail to analyze are reported inline and
+// collected in Errors, and the batch carries on with the next one.
+func (a *Analyzer) AnalyzeBatch(trxs []*eos.PackedTransaction) error {
+	count := len(trxs)
+	for idx, trx := range trxs {
+		if idx > 0 {
+			a.Pln()
+		}
+		a.Pf("[trx %d/%d]\n", idx+1, count)
+
+		var err error
+		if trx == nil {
+			err = fmt.Errorf("nil packed transaction")
+		} else {
+			err = a.AnalyzePacked(trx)
+		}
+		if err != nil {
+			err = fmt.Errorf("transaction %d/%d, %s", idx+1, count, err)
+			a.Pf("error analyzing %s\n", err)
+			a.Errors = append(a.Errors, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package analysis
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// testBlock returns block 101, made of a receipt for each of `trxs`,
+// a nil one standing for a deferred transaction.
+func testBlock(trxs ...*eos.PackedTransaction) *eos.SignedBlock {
+	block := &eos.SignedBlock{}
+	block.Producer = "bp1"
+	block.Timestamp = eos.BlockTimestamp{Time: time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)}
+	block.Previous = make(eos.SHA256Bytes, 32)
+	block.Previous[3] = 100
+
+	for _, trx := range trxs {
+		receipt := eos.TransactionReceipt{Transaction: eos.TransactionWithID{Packed: trx}}
+		receipt.Status = eos.TransactionStatusExecuted
+		receipt.CPUUsageMicroSeconds = 250
+		receipt.NetUsageWords = 16
+		if trx != nil {
+			receipt.Transaction.ID, _ = hex.DecodeString(ID(trx))
+		}
+		block.Transactions = append(block.Transactions, receipt)
+	}
+	return block
+}
+
+func TestAnalyzeBlock(t *testing.T) {
+	trx1 := testPack(t, testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "first")))
+	trx2 := testPack(t, testTransaction(testTransfer(t, "bob", "carol", "2.0000 EOS", "second")))
+
+	a := NewAnalyzer(false)
+	if err := a.AnalyzeBlock(testBlock(trx1, trx2)); err != nil {
+		t.Fatalf("AnalyzeBlock: %s", err)
+	}
+	out := a.String()
+	assertContains(t, out, "Block number: 101\nProducer: bp1\n")
+	assertContains(t, out, "Transactions: 2\n")
+	assertContains(t, out, "[trx 1/2] ID: "+ID(trx1)+", status: executed, CPU usage: 250us, net usage words: 16\n")
+	assertContains(t, out, "[trx 2/2] ID: "+ID(trx2)+", status: executed")
+	assertContains(t, out, `Transfer 1.0000 EOS from alice to bob, memo: "first"`)
+	assertContains(t, out, `Transfer 2.0000 EOS from bob to carol, memo: "second"`)
+}
+
+func TestAnalyzeBlockDeferred(t *testing.T) {
+	block := testBlock(nil)
+	block.Transactions[0].Transaction.ID = eos.SHA256Bytes{0xca, 0xfe}
+
+	a := NewAnalyzer(false)
+	if err := a.AnalyzeBlock(block); err != nil {
+		t.Fatalf("AnalyzeBlock: %s", err)
+	}
+	assertContains(t, a.String(), "[trx 1/1] ID: cafe, status: executed, CPU usage: 250us, net usage words: 16\nDeferred transaction, only referenced by ID\n")
+}
+
+func TestAnalyzeBatch(t *testing.T) {
+	good := testPack(t, testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "good")))
+	bad := &eos.PackedTransaction{PackedTransaction: []byte{0x01, 0x02}}
+
+	a := NewAnalyzer(false)
+	if err := a.AnalyzeBatch([]*eos.PackedTransaction{bad, good}); err != nil {
+		t.Fatalf("AnalyzeBatch: %s", err)
+	}
+	out := a.String()
+	assertContains(t, out, "[trx 1/2]\n")
+	assertContains(t, out, "error analyzing transaction 1/2, ")
+	assertContains(t, out, "[trx 2/2]\n")
+	assertContains(t, out, `Transfer 1.0000 EOS from alice to bob, memo: "good"`)
+	if len(a.Errors) != 1 {
+		t.Errorf("got errors %v, want 1", a.Errors)
+	}
+}
@@ -0,0 +1,51 @@
+package analysis
+
+import (
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/forum"
+	"github.com/eoscanada/eos-go/msig"
+	"github.com/eoscanada/eos-go/system"
+	"github.com/eoscanada/eos-go/token"
+)
+
+// Classify returns a coarse risk label for `tx`, based on the types of
+// its decoded actions: `token-transfer`, `governance`,
+// `contract-deploy`, `permission-change` or `privileged-exec` (for
+// `eosio.wrap::exec`, which bypasses authorization checks) when all
+// of its actions fall in that category, and `mixed/unknown`
+// otherwise.
+func (a *Analyzer) Classify(tx *eos.Transaction) string {
+	category := ""
+	for _, actions := range [][]*eos.Action{tx.ContextFreeActions, tx.Actions} {
+		for _, act := range actions {
+			actCategory := actionCategory(actionData(act))
+			if actCategory == "" || (category != "" && category != actCategory) {
+				return "mixed/unknown"
+			}
+			category = actCategory
+		}
+	}
+
+	if category == "" {
+		return "mixed/unknown"
+	}
+	return category
+}
+
+func actionCategory(data interface{}) string {
+	switch data.(type) {
+	case *token.Transfer:
+		return "token-transfer"
+	case *system.RegProducer, *system.RegProxy, *system.VoteProducer,
+		*forum.Post, *forum.Vote, *forum.Remove, *Unpost, *ForumPropose,
+		*msig.Propose, *msig.Approve, *msig.Exec, *msig.Cancel, *Invalidate:
+		return "governance"
+	case *WrapExec:
+		return "privileged-exec"
+	case *system.SetCode, *system.SetABI:
+		return "contract-deploy"
+	case *system.UpdateAuth, *DeleteAuth, *LinkAuth, *UnlinkAuth, *system.SetPriv:
+		return "permission-change"
+	}
+	return ""
+}
@@ -0,0 +1,35 @@
+package analysis
+
+import (
+	"testing"
+
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/system"
+)
+
+func TestClassify(t *testing.T) {
+	transfer := testTransfer(t, "alice", "bob", "1.0000 EOS", "")
+	setCode := testSetCode("mycontract", []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00})
+	vote := system.NewVoteProducer("alice", "", "bp1")
+	updateAuth := system.NewUpdateAuth("alice", "active", "owner", eos.Authority{Threshold: 1}, "owner")
+	unknown := testRawAction("mycontract", "doit", []byte{0x01})
+
+	tests := []struct {
+		name    string
+		actions []*eos.Action
+		want    string
+	}{
+		{"transfers", []*eos.Action{transfer, transfer}, "token-transfer"},
+		{"setcode", []*eos.Action{setCode}, "contract-deploy"},
+		{"vote", []*eos.Action{vote}, "governance"},
+		{"updateauth", []*eos.Action{updateAuth}, "permission-change"},
+		{"transfer and setcode", []*eos.Action{transfer, setCode}, "mixed/unknown"},
+		{"unknown action", []*eos.Action{unknown}, "mixed/unknown"},
+		{"no actions", nil, "mixed/unknown"},
+	}
+	for _, test := range tests {
+		if got := NewAnalyzer(false).Classify(testTransaction(test.actions...)); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package analysis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// ContentHash returns the hex-encoded sha256 of what `tx` does: its
+// actions and resource limits, leaving out the expiration, the
+// reference block and the extensions.
+//
+// Unlike `ID()`, it stays the same when the same transaction gets
+// rebuilt later on or against another head block, which is what a
+// cache keyed by content wants. Two transactions with the same
+// content hash may therefore have different IDs.
+func (a *Analyzer) ContentHash(tx *eos.Transaction) string {
+	normalized := &eos.Transaction{
+		TransactionHeader: eos.TransactionHeader{
+			MaxNetUsageWords: tx.MaxNetUsageWords,
+			MaxCPUUsageMS:    tx.MaxCPUUsageMS,
+			DelaySec:         tx.DelaySec,
+		},
+		ContextFreeActions: tx.ContextFreeActions,
+		Actions:            tx.Actions,
+	}
+
+	data, err := eos.MarshalBinary(normalized)
+	if err != nil {
+		return fmt.Sprintf("(unknown: %s)", err)
+	}
+
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,27 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+)
+
+func TestContentHash(t *testing.T) {
+	tx1 := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "rent"))
+	tx1.RefBlockNum, tx1.RefBlockPrefix = 1234, 5678
+	tx2 := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "rent"))
+	tx2.Expiration.Time = tx2.Expiration.Time.Add(time.Minute)
+	tx2.RefBlockNum, tx2.RefBlockPrefix = 4321, 8765
+
+	a := NewAnalyzer(false)
+	if h1, h2 := a.ContentHash(tx1), a.ContentHash(tx2); h1 != h2 {
+		t.Errorf("got different content hashes %s and %s, want the same", h1, h2)
+	}
+	if transactionID(tx1) == transactionID(tx2) {
+		t.Error("got the same ID for transactions with different headers")
+	}
+
+	tx3 := testTransaction(testTransfer(t, "alice", "bob", "2.0000 EOS", "rent"))
+	if a.ContentHash(tx1) == a.ContentHash(tx3) {
+		t.Error("got the same content hash for different transfers")
+	}
+}
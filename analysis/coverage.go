@@ -0,0 +1,153 @@
+package analysis
+
+import (
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/forum"
+	"github.com/eoscanada/eos-go/msig"
+	"github.com/eoscanada/eos-go/system"
+	"github.com/eoscanada/eos-go/token"
+)
+
+// RegisterRequiredPermission tells CheckAuthorizationCoverage that
+// the `account::name` actions require `level`, as a `linkauth` would
+// set it up. It replaces any permission previously registered for
+// them.
+func (a *Analyzer) RegisterRequiredPermission(account eos.AccountName, name eos.ActionName, level eos.PermissionLevel) {
+	if a.requiredPermissions == nil {
+		a.requiredPermissions = map[actionKey]eos.PermissionLevel{}
+	}
+	a.requiredPermissions[actionKey{account, name}] = level
+}
+
+// CheckAuthorizationCoverage warns about the actions of `tx` that
+// don't carry the authorization they require, which the chain or the
+// contract would then reject.
+//
+// The permissions registered with RegisterRequiredPermission are
+// checked first, satisfied by an authorization of that same permission
+// or of the actor's `owner`. Otherwise this is a heuristic, limited to
+// the known action types: it checks that the account the action acts
+// on behalf of (like the sender of a transfer) authorizes it, whatever
+// the permission. Context-free actions mustn't carry authorizations
+// at all.
+func (a *Analyzer) CheckAuthorizationCoverage(tx *eos.Transaction) {
+	for idx, act := range tx.ContextFreeActions {
+		if len(act.Authorization) > 0 {
+			a.warn("context-free action %d (%s::%s) carries authorizations, which context-free actions can't", idx+1, act.Account, act.Name)
+		}
+	}
+
+	for idx, act := range tx.Actions {
+		if level, ok := a.requiredPermissions[actionKey{act.Account, act.Name}]; ok {
+			if !coversPermission(act.Authorization, level) {
+				a.warn("action %d (%s::%s) isn't authorized by %s@%s", idx+1, act.Account, act.Name, level.Actor, level.Permission)
+			}
+			continue
+		}
+
+		actor := requiredActor(actionData(act))
+		if actor == "" {
+			continue
+		}
+
+		covered := false
+		for _, auth := range act.Authorization {
+			if auth.Actor == actor {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			a.warn("action %d (%s::%s) isn't authorized by %s", idx+1, act.Account, act.Name, actor)
+		}
+	}
+}
+
+// coversPermission returns whether `auths` satisfy `level`.
+func coversPermission(auths []eos.PermissionLevel, level eos.PermissionLevel) bool {
+	for _, auth := range auths {
+		if auth.Actor == level.Actor && (auth.Permission == level.Permission || auth.Permission == "owner") {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredActor returns the account whose authorization the decoded
+// action `data` requires, or an empty string when unknown.
+func requiredActor(data interface{}) eos.AccountName {
+	switch obj := data.(type) {
+	case *system.SetCode:
+		return obj.Account
+	case *system.SetABI:
+		return obj.Account
+	case *token.Transfer:
+		return obj.From
+	case *Open:
+		return obj.RAMPayer
+	case *Close:
+		return obj.Owner
+	case *system.DelegateBW:
+		return obj.From
+	case *system.UndelegateBW:
+		return obj.From
+	case *system.Refund:
+		return obj.Owner
+	case *system.RegProducer:
+		return obj.Producer
+	case *system.RegProxy:
+		return obj.Proxy
+	case *system.VoteProducer:
+		return obj.Voter
+	case *system.ClaimRewards:
+		return obj.Owner
+	case *system.Bidname:
+		return obj.Bidder
+	case *WrapExec:
+		return obj.Executer
+	case *msig.Propose:
+		return obj.Proposer
+	case *msig.Approve:
+		return obj.Level.Actor
+	case *msig.Exec:
+		return obj.Executer
+	case *msig.Cancel:
+		return obj.Canceler
+	case *Invalidate:
+		return obj.Account
+	case *system.NewAccount:
+		return obj.Creator
+	case *system.UpdateAuth:
+		return obj.Account
+	case *DeleteAuth:
+		return obj.Account
+	case *system.BuyRAM:
+		return obj.Payer
+	case *system.BuyRAMBytes:
+		return obj.Payer
+	case *SellRAM:
+		return obj.Account
+	case *PowerUp:
+		return obj.Payer
+	case *RentCPU:
+		return obj.From
+	case *RentNet:
+		return obj.From
+	case *LinkAuth:
+		return obj.Account
+	case *UnlinkAuth:
+		return obj.Account
+	case *forum.Post:
+		return obj.Account
+	case *forum.Vote:
+		return obj.Voter
+	case *forum.Remove:
+		return obj.Account
+	case *Unpost:
+		return obj.Poster
+	case *ForumPropose:
+		return obj.Proposer
+	}
+
+	return ""
+}
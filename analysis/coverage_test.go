@@ -0,0 +1,43 @@
+package analysis
+
+import (
+	"testing"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+func TestCheckAuthorizationCoverage(t *testing.T) {
+	uncovered := testTransfer(t, "alice", "bob", "1.0000 EOS", "")
+	uncovered.Authorization = []eos.PermissionLevel{{Actor: "bob", Permission: "active"}}
+
+	a := NewAnalyzer(false)
+	a.CheckAuthorizationCoverage(testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""), uncovered))
+	if out, want := a.String(), "WARNING: action 2 (eosio.token::transfer) isn't authorized by alice\n"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestCheckAuthorizationCoverageRegistered(t *testing.T) {
+	spender := eos.PermissionLevel{Actor: "alice", Permission: "spender"}
+	withActive := testTransfer(t, "alice", "bob", "1.0000 EOS", "")
+	withSpender := testTransfer(t, "alice", "bob", "1.0000 EOS", "")
+	withSpender.Authorization = []eos.PermissionLevel{spender}
+	withOwner := testTransfer(t, "alice", "bob", "1.0000 EOS", "")
+	withOwner.Authorization = []eos.PermissionLevel{{Actor: "alice", Permission: "owner"}}
+
+	a := NewAnalyzer(false)
+	a.RegisterRequiredPermission("eosio.token", "transfer", spender)
+	a.CheckAuthorizationCoverage(testTransaction(withActive, withSpender, withOwner))
+	if out, want := a.String(), "WARNING: action 1 (eosio.token::transfer) isn't authorized by alice@spender\n"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestCheckAuthorizationCoverageContextFree(t *testing.T) {
+	tx := testTransaction()
+	tx.ContextFreeActions = []*eos.Action{testRawAction("mycontract", "cfread", []byte{0x01})}
+
+	a := NewAnalyzer(false)
+	a.CheckAuthorizationCoverage(tx)
+	assertContains(t, a.String(), "WARNING: context-free action 1 (mycontract::cfread) carries authorizations, which context-free actions can't\n")
+}
@@ -0,0 +1,43 @@
+package analysis
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// AnalyzeCSV writes the actions of `tx` to `w` as CSV, with a header
+// row and then one row per action: its index (prefixed with `cf` for
+// context-free actions), account, name, authorizations and summary,
+// or raw hex data when it isn't decoded.
+func (a *Analyzer) AnalyzeCSV(tx *eos.Transaction, w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"index", "account", "name", "authorizations", "summary"}); err != nil {
+		return err
+	}
+
+	row := func(index string, act *eos.Action) error {
+		summary := a.summarize(act, actionData(act))
+		if summary == "" {
+			summary = hex.EncodeToString(act.HexData)
+		}
+		return out.Write([]string{index, string(act.Account), string(act.Name), strings.Join(authorizationStrings(act), " "), summary})
+	}
+	for idx, act := range tx.ContextFreeActions {
+		if err := row(fmt.Sprintf("cf%d", idx+1), act); err != nil {
+			return err
+		}
+	}
+	for idx, act := range tx.Actions {
+		if err := row(fmt.Sprintf("%d", idx+1), act); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,42 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestAnalyzeCSV(t *testing.T) {
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", "rent, march"),
+		testRawAction("mycontract", "doit", []byte{0xde, 0xad}),
+	)
+	tx.ContextFreeActions = append(tx.ContextFreeActions, testRawAction("mycontract", "log", nil))
+
+	var buf bytes.Buffer
+	if err := NewAnalyzer(false).AnalyzeCSV(tx, &buf); err != nil {
+		t.Fatalf("AnalyzeCSV: %s", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV back: %s", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("got %d rows, want 4: %q", len(rows), rows)
+	}
+
+	want := [][]string{
+		{"index", "account", "name", "authorizations", "summary"},
+		{"cf1", "mycontract", "log", "mycontract@active", ""},
+		{"1", "eosio.token", "transfer", "alice@active", `Transfer 1.0000 EOS from alice to bob, memo: "rent, march"`},
+		{"2", "mycontract", "doit", "mycontract@active", "dead"},
+	}
+	for idx, row := range rows {
+		for col, value := range row {
+			if value != want[idx][col] {
+				t.Errorf("row %d, column %d: got %q, want %q", idx, col, value, want[idx][col])
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package analysis
+
+import (
+	eos "github.com/eoscanada/eos-go"
+)
+
+// actionKey identifies an action by its contract and name.
+type actionKey struct {
+	account eos.AccountName
+	name    eos.ActionName
+}
+
+// RegisterDecoder makes `fn` provide the human-readable line of the
+// `account::name` actions, taking precedence over the built-in
+// decoding. It replaces any decoder previously registered for them.
+func (a *Analyzer) RegisterDecoder(account eos.AccountName, name eos.ActionName, fn func(*eos.Action) string) {
+	if a.decoders == nil {
+		a.decoders = map[actionKey]func(*eos.Action) string{}
+	}
+	a.decoders[actionKey{account, name}] = fn
+}
+
+// summarize returns the human-readable line of `act`, whose decoded
+// data is `data`, from its registered decoder if any.
+func (a *Analyzer) summarize(act *eos.Action, data interface{}) string {
+	if fn := a.decoders[actionKey{act.Account, act.Name}]; fn != nil {
+		return fn(act)
+	}
+	return a.actionSummary(data)
+}
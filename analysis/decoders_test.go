@@ -0,0 +1,34 @@
+package analysis
+
+import (
+	"fmt"
+	"testing"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+func TestRegisterDecoder(t *testing.T) {
+	a := NewAnalyzer(false)
+	a.Strict = true
+	a.RegisterDecoder("mygame", "move", func(act *eos.Action) string {
+		return fmt.Sprintf("Move of %d bytes", len(act.HexData))
+	})
+
+	out := testAnalyze(t, a, testTransaction(testRawAction("mygame", "move", []byte{1, 2, 3})))
+	assertContains(t, out, "Move of 3 bytes")
+}
+
+func TestRegisterDecoderOverridesBuiltin(t *testing.T) {
+	a := NewAnalyzer(false)
+	a.RegisterDecoder("eosio.token", "transfer", func(*eos.Action) string {
+		return "Custom transfer"
+	})
+	a.RegisterDecoder("eosio.token", "transfer", func(*eos.Action) string {
+		return "Replaced transfer"
+	})
+
+	out := testAnalyze(t, a, testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "")))
+	assertContains(t, out, "Replaced transfer")
+	assertNotContains(t, out, "Custom transfer")
+	assertNotContains(t, out, "Transfer 1.0000 EOS")
+}
@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/system"
+)
+
+// AnalyzeDeployment pairs the `setcode` and `setabi` actions of `tx`
+// by account, as a contract deployment normally updates both at once,
+// and warns about the accounts getting only one of the two.
+func (a *Analyzer) AnalyzeDeployment(tx *eos.Transaction) {
+	var accounts []eos.AccountName
+	code := map[eos.AccountName]bool{}
+	abi := map[eos.AccountName]bool{}
+	for _, act := range tx.Actions {
+		var account eos.AccountName
+		switch obj := actionData(act).(type) {
+		case *system.SetCode:
+			account = obj.Account
+			code[account] = true
+		case *system.SetABI:
+			account = obj.Account
+			abi[account] = true
+		default:
+			continue
+		}
+		if !contains(accounts, account) {
+			accounts = append(accounts, account)
+		}
+	}
+
+	if len(accounts) == 0 {
+		a.Pln("No contract deployment")
+		return
+	}
+
+	for _, account := range accounts {
+		switch {
+		case code[account] && abi[account]:
+			a.Pf("Contract deployment for %s: code + ABI updated\n", account)
+		case code[account]:
+			a.warn("code of %s updated without its ABI", account)
+		default:
+			a.warn("ABI of %s updated without its code", account)
+		}
+	}
+}
+
+func contains(accounts []eos.AccountName, account eos.AccountName) bool {
+	for _, acct := range accounts {
+		if acct == account {
+			return true
+		}
+	}
+	return false
+}
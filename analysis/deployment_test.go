@@ -0,0 +1,27 @@
+package analysis
+
+import "testing"
+
+func TestAnalyzeDeployment(t *testing.T) {
+	a := NewAnalyzer(false)
+	a.AnalyzeDeployment(testTransaction(testSetCode("mycontract", testWasm), testSetABI(t, "mycontract", testTokenABI())))
+	if out, want := a.String(), "Contract deployment for mycontract: code + ABI updated\n"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestAnalyzeDeploymentCodeOnly(t *testing.T) {
+	a := NewAnalyzer(false)
+	a.AnalyzeDeployment(testTransaction(testSetCode("mycontract", testWasm), testSetABI(t, "other", testTokenABI())))
+	out := a.String()
+	assertContains(t, out, "WARNING: code of mycontract updated without its ABI\n")
+	assertContains(t, out, "WARNING: ABI of other updated without its code\n")
+}
+
+func TestAnalyzeDeploymentNone(t *testing.T) {
+	a := NewAnalyzer(false)
+	a.AnalyzeDeployment(testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "")))
+	if out, want := a.String(), "No contract deployment\n"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
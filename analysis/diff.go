@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// Diff compares `tx1` and `tx2` field by field, and prints every
+// mismatch on its own line, or `transactions are identical`.
+func (a *Analyzer) Diff(tx1, tx2 *eos.Transaction) {
+	diffs := diffTransactions(tx1, tx2)
+	if len(diffs) == 0 {
+		a.Pln("transactions are identical")
+		return
+	}
+
+	for _, diff := range diffs {
+		a.Pln(diff)
+	}
+}
+
+func diffTransactions(tx1, tx2 *eos.Transaction) (out []string) {
+	diff := func(field string, v1, v2 interface{}) {
+		if v1 != v2 {
+			out = append(out, fmt.Sprintf("%s: %v != %v", field, v1, v2))
+		}
+	}
+
+	if !tx1.Expiration.Time.Equal(tx2.Expiration.Time) {
+		out = append(out, fmt.Sprintf("expiration: %s != %s", tx1.Expiration.Time, tx2.Expiration.Time))
+	}
+	diff("ref block num", tx1.RefBlockNum, tx2.RefBlockNum)
+	diff("ref block prefix", tx1.RefBlockPrefix, tx2.RefBlockPrefix)
+	diff("max net usage words", tx1.MaxNetUsageWords, tx2.MaxNetUsageWords)
+	diff("max cpu usage ms", tx1.MaxCPUUsageMS, tx2.MaxCPUUsageMS)
+	diff("delay sec", tx1.DelaySec, tx2.DelaySec)
+
+	out = append(out, diffActions("context-free action", tx1.ContextFreeActions, tx2.ContextFreeActions)...)
+	out = append(out, diffActions("action", tx1.Actions, tx2.Actions)...)
+
+	return
+}
+
+func diffActions(label string, actions1, actions2 []*eos.Action) (out []string) {
+	if len(actions1) != len(actions2) {
+		out = append(out, fmt.Sprintf("%s count: %d != %d", label, len(actions1), len(actions2)))
+	}
+
+	for idx := 0; idx < len(actions1) && idx < len(actions2); idx++ {
+		act1, act2 := actions1[idx], actions2[idx]
+		prefix := fmt.Sprintf("%s %d", label, idx+1)
+
+		if act1.Account != act2.Account {
+			out = append(out, fmt.Sprintf("%s account: %s != %s", prefix, act1.Account, act2.Account))
+		}
+		if act1.Name != act2.Name {
+			out = append(out, fmt.Sprintf("%s name: %s != %s", prefix, act1.Name, act2.Name))
+		}
+
+		auths1 := strings.Join(authorizationStrings(act1), ", ")
+		auths2 := strings.Join(authorizationStrings(act2), ", ")
+		if auths1 != auths2 {
+			out = append(out, fmt.Sprintf("%s authorizations: [%s] != [%s]", prefix, auths1, auths2))
+		}
+
+		data1, err1 := eos.MarshalBinary(act1.ActionData)
+		data2, err2 := eos.MarshalBinary(act2.ActionData)
+		if err1 != nil || err2 != nil {
+			out = append(out, fmt.Sprintf("%s data: couldn't serialize for comparison", prefix))
+		} else if !bytes.Equal(data1, data2) {
+			out = append(out, fmt.Sprintf("%s data: %x != %x", prefix, data1, data2))
+		}
+	}
+
+	return
+}
@@ -0,0 +1,31 @@
+package analysis
+
+import (
+	"testing"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+func TestDiffIdentical(t *testing.T) {
+	tx1 := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "hi"))
+	tx2 := *tx1
+	tx2.Actions = []*eos.Action{testTransfer(t, "alice", "bob", "1.0000 EOS", "hi")}
+
+	a := NewAnalyzer(false)
+	a.Diff(tx1, &tx2)
+	if out := a.String(); out != "transactions are identical\n" {
+		t.Errorf("got %q, want the transactions to be identical", out)
+	}
+}
+
+func TestDiffDelaySec(t *testing.T) {
+	tx1 := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "hi"))
+	tx2 := *tx1
+	tx2.DelaySec = 3600
+
+	a := NewAnalyzer(false)
+	a.Diff(tx1, &tx2)
+	if out := a.String(); out != "delay sec: 0 != 3600\n" {
+		t.Errorf("got %q, want only the delay to differ", out)
+	}
+}
@@ -0,0 +1,96 @@
+package analysis
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// analyzeEmbedded analyzes, as nested transactions, the fields of
+// `act` that EmbeddedTransactionFields lists for it. Its data is
+// decoded (through eos-go types or a registered ABI) and looked at as
+// JSON, where a field holds either a packed transaction in hex or the
+// transaction itself.
+func (a *Analyzer) analyzeEmbedded(act *eos.Action) error {
+	fields := a.EmbeddedTransactionFields[fmt.Sprintf("%s::%s", act.Account, act.Name)]
+	if len(fields) == 0 {
+		return nil
+	}
+
+	decoded := actionData(act)
+	if decoded == nil {
+		abi := a.abis[act.Account]
+		if abi == nil {
+			a.warn("no ABI known for %s::%s, embedded transactions not analyzed", act.Account, act.Name)
+			return nil
+		}
+		var err error
+		if decoded, err = decodeABIAction(abi, act.Name, act.HexData); err != nil {
+			a.Pf("Couldn't decode data to find embedded transactions: %s\n", err)
+			return nil
+		}
+	}
+
+	cnt, err := json.Marshal(decoded)
+	if err != nil {
+		a.Pf("Couldn't serialize data to find embedded transactions: %s\n", err)
+		return nil
+	}
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(cnt, &values); err != nil {
+		a.Pf("Couldn't find embedded transactions in data: %s\n", err)
+		return nil
+	}
+
+	for _, field := range fields {
+		raw, ok := values[field]
+		if !ok {
+			a.warn("no field %q in %s::%s to find an embedded transaction in", field, act.Account, act.Name)
+			continue
+		}
+
+		tx, err := embeddedTransaction(raw)
+		if err != nil {
+			a.Pf("Couldn't decode embedded transaction in field %q: %s\n", field, err)
+			continue
+		}
+
+		a.Pf(">>>>>>>>>>>>>>>>>>>>>>> EMBEDDED TRANSACTION (%s) >>>>>>>>>>>>>>>>>>>>>>>\n", field)
+		if err := a.analyzeNested(tx); err != nil {
+			return err
+		}
+		a.Pf("<<<<<<<<<<<<<<<<<<<<< END EMBEDDED TRANSACTION (%s) <<<<<<<<<<<<<<<<<<<<<\n", field)
+	}
+
+	return nil
+}
+
+// embeddedTransaction reads a transaction from the JSON `raw` value,
+// either a hex string of the packed transaction or a JSON object.
+func embeddedTransaction(raw json.RawMessage) (*eos.Transaction, error) {
+	tx := &eos.Transaction{}
+
+	var hexData string
+	if err := json.Unmarshal(raw, &hexData); err == nil {
+		data, err := hex.DecodeString(hexData)
+		if err != nil {
+			return nil, fmt.Errorf("decoding hex, %s", err)
+		}
+		if err := eos.UnmarshalBinary(data, tx); err != nil {
+			return nil, fmt.Errorf("unpacking transaction, %s", err)
+		}
+		for _, act := range tx.Actions {
+			if err := unpackActionData(act); err != nil {
+				return nil, err
+			}
+		}
+		return tx, nil
+	}
+
+	if err := json.Unmarshal(raw, tx); err != nil {
+		return nil, fmt.Errorf("reading transaction, %s", err)
+	}
+	return tx, nil
+}
@@ -0,0 +1,48 @@
+package analysis
+
+import (
+	"testing"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// testRelayABI returns the ABI of a made-up `myrelay` contract, whose
+// `relay` action holds a packed transaction.
+func testRelayABI() *eos.ABI {
+	return &eos.ABI{
+		Version: "eosio::abi/1.0",
+		Structs: []eos.StructDef{
+			{Name: "relay", Fields: []eos.FieldDef{{Name: "sender", Type: "name"}, {Name: "trx", Type: "bytes"}}},
+		},
+		Actions: []eos.ActionDef{{Name: "relay", Type: "relay"}},
+	}
+}
+
+func TestAnalyzeEmbeddedTransaction(t *testing.T) {
+	inner, err := eos.MarshalBinary(testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "relayed")))
+	if err != nil {
+		t.Fatalf("packing embedded transaction: %s", err)
+	}
+	data, err := eos.MarshalBinary(struct {
+		Sender eos.Name
+		Trx    []byte
+	}{"alice", inner})
+	if err != nil {
+		t.Fatalf("packing relay: %s", err)
+	}
+	tx := testTransaction(testRawAction("myrelay", "relay", data))
+
+	a := NewAnalyzer(false)
+	a.RegisterABI("myrelay", testRelayABI())
+	a.EmbeddedTransactionFields = map[string][]string{"myrelay::relay": {"trx", "missing"}}
+	out := testAnalyze(t, a, tx)
+	assertContains(t, out, ">>>>>>>>>>>>>>>>>>>>>>> EMBEDDED TRANSACTION (trx) >>>>>>>>>>>>>>>>>>>>>>>\n")
+	assertContains(t, out, `Transfer 1.0000 EOS from alice to bob, memo: "relayed"`)
+	assertContains(t, out, "<<<<<<<<<<<<<<<<<<<<< END EMBEDDED TRANSACTION (trx) <<<<<<<<<<<<<<<<<<<<<\n")
+	assertContains(t, out, `WARNING: no field "missing" in myrelay::relay to find an embedded transaction in`)
+
+	a = NewAnalyzer(false)
+	a.RegisterABI("myrelay", testRelayABI())
+	out = testAnalyze(t, a, tx)
+	assertNotContains(t, out, "EMBEDDED TRANSACTION")
+}
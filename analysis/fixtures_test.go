@@ -0,0 +1,138 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+	"github.com/eoscanada/eos-go/system"
+	"github.com/eoscanada/eos-go/token"
+)
+
+func testAsset(t *testing.T, quantity string) eos.Asset {
+	t.Helper()
+	asset, err := eos.NewAsset(quantity)
+	if err != nil {
+		t.Fatalf("parsing asset %q: %s", quantity, err)
+	}
+	return asset
+}
+
+func testTransfer(t *testing.T, from, to eos.AccountName, quantity, memo string) *eos.Action {
+	t.Helper()
+	return token.NewTransfer(from, to, testAsset(t, quantity), memo)
+}
+
+// testRawAction returns an `account::name` action authorized by
+// `account@active`, carrying `data` as is.
+func testRawAction(account eos.AccountName, name eos.ActionName, data []byte) *eos.Action {
+	return &eos.Action{
+		Account:       account,
+		Name:          name,
+		Authorization: []eos.PermissionLevel{{Actor: account, Permission: "active"}},
+		ActionData:    eos.ActionData{HexData: data},
+	}
+}
+
+// testKey returns a fresh key pair.
+func testKey(t *testing.T) *ecc.PrivateKey {
+	t.Helper()
+	key, err := ecc.NewRandomPrivateKey()
+	if err != nil {
+		t.Fatalf("generating key: %s", err)
+	}
+	return key
+}
+
+// testAction returns an `account::name` action authorized by
+// `actor@active`, carrying `data`.
+func testAction(account eos.AccountName, name eos.ActionName, actor eos.AccountName, data interface{}) *eos.Action {
+	return &eos.Action{
+		Account:       account,
+		Name:          name,
+		Authorization: []eos.PermissionLevel{{Actor: actor, Permission: "active"}},
+		ActionData:    eos.NewActionData(data),
+	}
+}
+
+// testWasm is the smallest WebAssembly module: its header alone.
+var testWasm = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+func testSetCode(account eos.AccountName, code []byte) *eos.Action {
+	return testAction("eosio", "setcode", account, system.SetCode{Account: account, Code: code})
+}
+
+// testTokenABI returns the ABI of the `eosio.token` contract.
+func testTokenABI() *eos.ABI {
+	return &eos.ABI{
+		Version: "eosio::abi/1.0",
+		Structs: []eos.StructDef{
+			{Name: "account", Fields: []eos.FieldDef{{Name: "balance", Type: "asset"}}},
+			{Name: "currency_stats", Fields: []eos.FieldDef{{Name: "supply", Type: "asset"}, {Name: "max_supply", Type: "asset"}, {Name: "issuer", Type: "name"}}},
+			{Name: "create", Fields: []eos.FieldDef{{Name: "issuer", Type: "name"}, {Name: "maximum_supply", Type: "asset"}}},
+			{Name: "issue", Fields: []eos.FieldDef{{Name: "to", Type: "name"}, {Name: "quantity", Type: "asset"}, {Name: "memo", Type: "string"}}},
+			{Name: "transfer", Fields: []eos.FieldDef{{Name: "from", Type: "name"}, {Name: "to", Type: "name"}, {Name: "quantity", Type: "asset"}, {Name: "memo", Type: "string"}}},
+		},
+		Actions: []eos.ActionDef{
+			{Name: "transfer", Type: "transfer"},
+			{Name: "issue", Type: "issue"},
+			{Name: "create", Type: "create"},
+		},
+		Tables: []eos.TableDef{
+			{Name: "accounts", IndexType: "i64", Type: "account"},
+			{Name: "stat", IndexType: "i64", Type: "currency_stats"},
+		},
+	}
+}
+
+// testSetABI returns a `setabi` action deploying `abi` to `account`.
+func testSetABI(t *testing.T, account eos.AccountName, abi *eos.ABI) *eos.Action {
+	t.Helper()
+	cnt, err := eos.MarshalBinary(abi)
+	if err != nil {
+		t.Fatalf("packing ABI: %s", err)
+	}
+	return testAction("eosio", "setabi", account, system.SetABI{Account: account, ABI: cnt})
+}
+
+// testTransaction returns a transaction of `actions`, expiring in an
+// hour.
+func testTransaction(actions ...*eos.Action) *eos.Transaction {
+	tx := &eos.Transaction{Actions: actions}
+	tx.Expiration = eos.JSONTime{Time: time.Now().UTC().Add(time.Hour).Truncate(time.Second)}
+	return tx
+}
+
+func testPack(t *testing.T, tx *eos.Transaction) *eos.PackedTransaction {
+	t.Helper()
+	trx, err := eos.NewSignedTransaction(tx).Pack(eos.CompressionNone)
+	if err != nil {
+		t.Fatalf("packing transaction: %s", err)
+	}
+	return trx
+}
+
+func assertContains(t *testing.T, out, want string) {
+	t.Helper()
+	if !strings.Contains(out, want) {
+		t.Errorf("output doesn't contain %q:\n%s", want, out)
+	}
+}
+
+func assertNotContains(t *testing.T, out, unwanted string) {
+	t.Helper()
+	if strings.Contains(out, unwanted) {
+		t.Errorf("output contains %q:\n%s", unwanted, out)
+	}
+}
+
+// testAnalyze packs `tx`, analyzes it with `a` and returns the output.
+func testAnalyze(t *testing.T, a *Analyzer, tx *eos.Transaction) string {
+	t.Helper()
+	if err := a.AnalyzePacked(testPack(t, tx)); err != nil {
+		t.Fatalf("AnalyzePacked: %s", err)
+	}
+	return a.String()
+}
@@ -0,0 +1,115 @@
+package analysis
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// AnalyzePackedHex analyzes a binary-packed `PackedTransaction`,
+// given as a hex string (like the ones wallets let you copy). An
+// optional `0x` prefix and surrounding whitespace are ignored.
+func (a *Analyzer) AnalyzePackedHex(hexStr string) error {
+	hexStr = strings.TrimSpace(hexStr)
+	hexStr = strings.TrimPrefix(strings.TrimPrefix(hexStr, "0x"), "0X")
+
+	data, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return fmt.Errorf("decoding hex, %s", err)
+	}
+
+	var trx eos.PackedTransaction
+	if err := eos.UnmarshalBinary(data, &trx); err != nil {
+		return fmt.Errorf("unpacking packed transaction, %s", err)
+	}
+
+	return a.AnalyzePacked(&trx)
+}
+
+// AnalyzePackedReader analyzes a binary-packed `PackedTransaction`
+// read from `r`, up to MaxInputBytes.
+func (a *Analyzer) AnalyzePackedReader(r io.Reader) error {
+	if a.MaxInputBytes > 0 {
+		r = io.LimitReader(r, a.MaxInputBytes+1)
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("reading packed transaction, %s", err)
+	}
+	if a.MaxInputBytes > 0 && int64(len(data)) > a.MaxInputBytes {
+		return fmt.Errorf("packed transaction larger than %d bytes", a.MaxInputBytes)
+	}
+
+	var trx eos.PackedTransaction
+	if err := eos.UnmarshalBinary(data, &trx); err != nil {
+		return fmt.Errorf("unpacking packed transaction, %s", err)
+	}
+
+	return a.AnalyzePacked(&trx)
+}
+
+// AnalyzeSignedJSON analyzes a `SignedTransaction` in its JSON form,
+// like the ones `cleos` outputs. Action data may be given either as
+// a hex string or as a JSON object, and is decoded when its ABI is
+// known.
+func (a *Analyzer) AnalyzeSignedJSON(data []byte) error {
+	var sTx eos.SignedTransaction
+	if err := json.Unmarshal(data, &sTx); err != nil {
+		return fmt.Errorf("unmarshalling signed transaction, %s", err)
+	}
+	if sTx.Transaction == nil {
+		return fmt.Errorf("signed transaction has no transaction")
+	}
+
+	for _, actions := range [][]*eos.Action{sTx.ContextFreeActions, sTx.Actions} {
+		for _, act := range actions {
+			if err := decodeJSONActionData(act); err != nil {
+				if !a.AccumulateErrors {
+					return err
+				}
+				a.recordActionError(act, err)
+			}
+		}
+	}
+
+	if !a.Compact {
+		if a.shows(SectionHeader) {
+			a.section("SIGNED TRANSACTION")
+		}
+		if a.shows(SectionSignatures) {
+			a.Pf("Signatures: %q\n", sTx.Signatures)
+		}
+		if a.shows(SectionHeader) {
+			a.analyzeContextFreeData(&sTx)
+		}
+	}
+
+	return a.AnalyzeSignedTransaction(&sTx)
+}
+
+// decodeJSONActionData turns the JSON `data` of `act`, either a hex
+// string or an object, into its registered type.
+func decodeJSONActionData(act *eos.Action) error {
+	switch data := act.ActionData.Data.(type) {
+	case string:
+		hexData, err := hex.DecodeString(data)
+		if err != nil {
+			return fmt.Errorf("decoding hex data of action %s::%s, %s", act.Account, act.Name, err)
+		}
+		act.ActionData.HexData = hexData
+		act.ActionData.Data = nil
+	case map[string]interface{}:
+		return act.MapToRegisteredAction()
+	}
+
+	if len(act.ActionData.HexData) == 0 {
+		return nil
+	}
+	return unpackActionData(act)
+}
@@ -0,0 +1,137 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// testPackedBytes returns the binary form of the PackedTransaction of
+// a transfer, as AnalyzePackedHex and AnalyzePackedReader expect it.
+func testPackedBytes(t *testing.T) (*eos.PackedTransaction, []byte) {
+	t.Helper()
+	trx := testPack(t, testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "from hex")))
+
+	// `eos-go` can't encode a CompressionType, so this is done by hand:
+	// no signatures, the compression, then both payloads.
+	data := []byte{0x00, byte(trx.Compression)}
+	for _, payload := range [][]byte{trx.PackedContextFreeData, trx.PackedTransaction} {
+		cnt, err := eos.MarshalBinary(eos.HexBytes(payload))
+		if err != nil {
+			t.Fatalf("packing payload: %s", err)
+		}
+		data = append(data, cnt...)
+	}
+	return trx, data
+}
+
+func TestAnalyzePackedHex(t *testing.T) {
+	trx, data := testPackedBytes(t)
+
+	for _, input := range []string{hex.EncodeToString(data), "0x" + hex.EncodeToString(data) + "\n"} {
+		a := NewAnalyzer(false)
+		if err := a.AnalyzePackedHex(input); err != nil {
+			t.Fatalf("AnalyzePackedHex(%q): %s", input, err)
+		}
+		out := a.String()
+		assertContains(t, out, ID(trx))
+		assertContains(t, out, `Transfer 1.0000 EOS from alice to bob, memo: "from hex"`)
+	}
+}
+
+func TestAnalyzePackedHexInvalid(t *testing.T) {
+	if err := NewAnalyzer(false).AnalyzePackedHex("0xzz"); err == nil {
+		t.Error("AnalyzePackedHex succeeded on invalid hex")
+	}
+}
+
+func TestAnalyzePackedReader(t *testing.T) {
+	trx, data := testPackedBytes(t)
+
+	a := NewAnalyzer(false)
+	if err := a.AnalyzePackedReader(bytes.NewReader(data)); err != nil {
+		t.Fatalf("AnalyzePackedReader: %s", err)
+	}
+	out := a.String()
+	assertContains(t, out, ID(trx))
+	assertContains(t, out, `Transfer 1.0000 EOS from alice to bob, memo: "from hex"`)
+}
+
+func TestAnalyzePackedReaderTooLarge(t *testing.T) {
+	_, data := testPackedBytes(t)
+
+	a := NewAnalyzer(false)
+	a.MaxInputBytes = int64(len(data) - 1)
+	if err := a.AnalyzePackedReader(bytes.NewReader(data)); err == nil {
+		t.Error("AnalyzePackedReader succeeded past MaxInputBytes")
+	}
+
+	a = NewAnalyzer(false)
+	a.MaxInputBytes = int64(len(data))
+	if err := a.AnalyzePackedReader(bytes.NewReader(data)); err != nil {
+		t.Errorf("AnalyzePackedReader at exactly MaxInputBytes: %s", err)
+	}
+}
+
+// testSignedJSON is a signed transaction as `cleos` outputs it, with
+// a context-free action, its data blob and a transfer whose data is
+// given as a JSON object.
+const testSignedJSON = `{
+  "expiration": "2018-06-01T12:00:00",
+  "ref_block_num": 1234,
+  "ref_block_prefix": 5678,
+  "max_net_usage_words": 0,
+  "max_cpu_usage_ms": 0,
+  "delay_sec": 0,
+  "context_free_actions": [{
+    "account": "mycontract",
+    "name": "cfread",
+    "authorization": [],
+    "data": "01"
+  }],
+  "actions": [{
+    "account": "eosio.token",
+    "name": "transfer",
+    "authorization": [{"actor": "alice", "permission": "active"}],
+    "data": {"from": "alice", "to": "bob", "quantity": "1.0000 EOS", "memo": "from cleos"}
+  }],
+  "transaction_extensions": [],
+  "signatures": [],
+  "context_free_data": ["cafebabe"]
+}`
+
+func TestAnalyzeSignedJSON(t *testing.T) {
+	a := NewAnalyzer(false)
+	if err := a.AnalyzeSignedJSON([]byte(testSignedJSON)); err != nil {
+		t.Fatalf("AnalyzeSignedJSON: %s", err)
+	}
+	out := a.String()
+	assertContains(t, out, "Number of context-free data blobs (on Transaction): 1\n1. Blob length: 4\n")
+	assertContains(t, out, "Context-free actions: 1\n")
+	assertContains(t, out, `Transfer 1.0000 EOS from alice to bob, memo: "from cleos"`)
+	assertNotContains(t, out, "context-free data blob(s)")
+}
+
+func TestAnalyzeSignedJSONInvalid(t *testing.T) {
+	if err := NewAnalyzer(false).AnalyzeSignedJSON([]byte(`{"signatures": []}`)); err == nil {
+		t.Error("AnalyzeSignedJSON succeeded without a transaction")
+	}
+}
+
+func TestAnalyzeJSONActionData(t *testing.T) {
+	act := testTransfer(t, "alice", "bob", "1.0000 EOS", "")
+	act.ActionData = eos.ActionData{Data: map[string]interface{}{
+		"from":     "alice",
+		"to":       "bob",
+		"quantity": "1.5000 EOS",
+		"memo":     "before packing",
+	}}
+
+	a := NewAnalyzer(false)
+	if err := a.AnalyzeTransaction(testTransaction(act)); err != nil {
+		t.Fatalf("AnalyzeTransaction: %s", err)
+	}
+	assertContains(t, a.String(), `Transfer 1.5000 EOS from alice to bob, memo: "before packing"`)
+}
@@ -0,0 +1,33 @@
+package analysis
+
+import (
+	eos "github.com/eoscanada/eos-go"
+)
+
+// AnalyzeResources prints the NET and CPU caps `tx` puts on itself,
+// in their raw units as well as in bytes and microseconds. When
+// ChainMaxNetBytes or ChainMaxCPUMS are set, caps are also given as a
+// percentage of them.
+func (a *Analyzer) AnalyzeResources(tx *eos.Transaction) {
+	a.section("RESOURCES")
+
+	if tx.MaxNetUsageWords == 0 {
+		a.Pln("Maximum NET usage: unlimited")
+		a.note("no NET cap, the transaction may use up to the chain's limit")
+	} else {
+		a.Pf("Maximum NET usage: %d words (%d bytes)\n", tx.MaxNetUsageWords, uint64(tx.MaxNetUsageWords)*8)
+		if a.ChainMaxNetBytes > 0 {
+			a.Pf("NET cap is %.1f%% of chain max\n", float64(tx.MaxNetUsageWords)*8*100/float64(a.ChainMaxNetBytes))
+		}
+	}
+
+	if tx.MaxCPUUsageMS == 0 {
+		a.Pln("Maximum CPU usage: unlimited")
+		a.note("no CPU cap, the transaction may use up to the chain's limit")
+	} else {
+		a.Pf("Maximum CPU usage: %d ms (%d us)\n", tx.MaxCPUUsageMS, uint64(tx.MaxCPUUsageMS)*1000)
+		if a.ChainMaxCPUMS > 0 {
+			a.Pf("CPU cap is %.1f%% of chain max\n", float64(tx.MaxCPUUsageMS)*100/float64(a.ChainMaxCPUMS))
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package analysis
+
+import "testing"
+
+func TestAnalyzeResources(t *testing.T) {
+	tx := testTransaction()
+	tx.MaxNetUsageWords = 128
+	tx.MaxCPUUsageMS = 5
+
+	a := NewAnalyzer(false)
+	a.ChainMaxNetBytes = 4096
+	a.ChainMaxCPUMS = 50
+	a.AnalyzeResources(tx)
+	out := a.String()
+	assertContains(t, out, "Maximum NET usage: 128 words (1024 bytes)\nNET cap is 25.0% of chain max\n")
+	assertContains(t, out, "Maximum CPU usage: 5 ms (5000 us)\nCPU cap is 10.0% of chain max\n")
+	assertNotContains(t, out, "unlimited")
+}
+
+func TestAnalyzeResourcesUnlimited(t *testing.T) {
+	a := NewAnalyzer(false)
+	a.AnalyzeResources(testTransaction())
+	out := a.String()
+	assertContains(t, out, "Maximum NET usage: unlimited\nNOTE: no NET cap")
+	assertContains(t, out, "Maximum CPU usage: unlimited\nNOTE: no CPU cap")
+}
+
+func TestAnalyzeResourcesChainMax(t *testing.T) {
+	tx := testTransaction()
+	tx.MaxNetUsageWords = 100
+	tx.MaxCPUUsageMS = 30
+
+	a := NewAnalyzer(false)
+	a.ChainMaxNetBytes = 524288
+	a.ChainMaxCPUMS = 150
+	a.AnalyzeResources(tx)
+	out := a.String()
+	assertContains(t, out, "NET cap is 0.2% of chain max\n")
+	assertContains(t, out, "CPU cap is 20.0% of chain max\n")
+
+	a = NewAnalyzer(false)
+	a.AnalyzeResources(tx)
+	assertNotContains(t, a.String(), "of chain max")
+}
@@ -0,0 +1,191 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"text/template"
+	"time"
+
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+	"github.com/vmihailenco/msgpack"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Result is the structured counterpart of the text analysis, meant
+// to be consumed by tooling rather than read by humans.
+type Result struct {
+	ID                 string          `json:"id" msgpack:"id"`
+	Signatures         []ecc.Signature `json:"signatures" msgpack:"signatures"`
+	Expiration         time.Time       `json:"expiration" msgpack:"expiration"`
+	RefBlockNum        uint16          `json:"ref_block_num" msgpack:"ref_block_num"`
+	RefBlockPrefix     uint32          `json:"ref_block_prefix" msgpack:"ref_block_prefix"`
+	ContextFreeActions []DecodedAction `json:"context_free_actions" msgpack:"context_free_actions"`
+	Actions            []DecodedAction `json:"actions" msgpack:"actions"`
+}
+
+// TransactionHeader is the structured counterpart of the printed
+// transaction header, with plain Go types.
+type TransactionHeader struct {
+	Expiration       time.Time `json:"expiration"`
+	RefBlockNum      uint16    `json:"ref_block_num"`
+	RefBlockPrefix   uint32    `json:"ref_block_prefix"`
+	MaxNetUsageWords uint32    `json:"max_net_usage_words"`
+	MaxCPUUsageMS    uint8     `json:"max_cpu_usage_ms"`
+	DelaySec         uint32    `json:"delay_sec"`
+}
+
+// Header returns the header fields of `tx`.
+func (a *Analyzer) Header(tx *eos.Transaction) TransactionHeader {
+	return TransactionHeader{
+		Expiration:       tx.Expiration.Time,
+		RefBlockNum:      tx.RefBlockNum,
+		RefBlockPrefix:   tx.RefBlockPrefix,
+		MaxNetUsageWords: uint32(tx.MaxNetUsageWords),
+		MaxCPUUsageMS:    tx.MaxCPUUsageMS,
+		DelaySec:         uint32(tx.DelaySec),
+	}
+}
+
+// DecodedAction is an action along with its decoded data when a
+// known ABI applies, or its raw hex data otherwise.
+type DecodedAction struct {
+	Account        eos.AccountName `json:"account" msgpack:"account"`
+	Name           eos.ActionName  `json:"name" msgpack:"name"`
+	Authorizations []string        `json:"authorizations" msgpack:"authorizations"`
+	Data           interface{}     `json:"data,omitempty" msgpack:"data,omitempty"`
+}
+
+// AnalyzePackedJSON unpacks `trx` and returns the JSON
+// representation of its Result.
+func (a *Analyzer) AnalyzePackedJSON(trx *eos.PackedTransaction) ([]byte, error) {
+	res, _, err := a.packedResult(trx)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(res, "", "  ")
+}
+
+// AnalyzePackedYAML unpacks `trx` and returns the YAML
+// representation of its Result.
+//
+// It goes through the JSON representation first, so that YAML
+// output matches JSON output: timestamps as RFC3339 strings, binary
+// blobs as hex strings, and fields keeping their order and names.
+func (a *Analyzer) AnalyzePackedYAML(trx *eos.PackedTransaction) ([]byte, error) {
+	cnt, err := a.AnalyzePackedJSON(trx)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.MapSlice
+	if err := yaml.Unmarshal(cnt, &doc); err != nil {
+		return nil, fmt.Errorf("converting JSON to YAML, %s", err)
+	}
+
+	return yaml.Marshal(doc)
+}
+
+// AnalyzePackedMsgpack unpacks `trx` and returns the MessagePack
+// representation of its Result. Unlike in JSON, the raw data of
+// actions without a known ABI is kept as bytes rather than hex.
+func (a *Analyzer) AnalyzePackedMsgpack(trx *eos.PackedTransaction) ([]byte, error) {
+	res, sTx, err := a.packedResult(trx)
+	if err != nil {
+		return nil, err
+	}
+
+	rawData(res.ContextFreeActions, sTx.ContextFreeActions)
+	rawData(res.Actions, sTx.Actions)
+
+	return msgpack.Marshal(res)
+}
+
+// rawData swaps the hex data of the `decoded` actions that have no
+// known ABI for the raw bytes of their `actions` counterpart.
+func rawData(decoded []DecodedAction, actions []*eos.Action) {
+	for idx := range decoded {
+		if _, ok := decoded[idx].Data.(string); ok {
+			decoded[idx].Data = []byte(actions[idx].HexData)
+		}
+	}
+}
+
+func (a *Analyzer) packedResult(trx *eos.PackedTransaction) (*Result, *eos.SignedTransaction, error) {
+	sTx, err := a.Unpack(trx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unpacking transaction, %s", err)
+	}
+
+	res := transactionResult(sTx.Transaction)
+	res.ID = ID(trx)
+	res.Signatures = trx.Signatures
+
+	return res, sTx, nil
+}
+
+func transactionResult(tx *eos.Transaction) *Result {
+	res := &Result{
+		ID:                 transactionID(tx),
+		Signatures:         []ecc.Signature{},
+		Expiration:         tx.Expiration.Time,
+		RefBlockNum:        tx.RefBlockNum,
+		RefBlockPrefix:     tx.RefBlockPrefix,
+		ContextFreeActions: []DecodedAction{},
+		Actions:            []DecodedAction{},
+	}
+
+	for _, act := range tx.ContextFreeActions {
+		res.ContextFreeActions = append(res.ContextFreeActions, decodeAction(act))
+	}
+	for _, act := range tx.Actions {
+		res.Actions = append(res.Actions, decodeAction(act))
+	}
+
+	return res
+}
+
+// AnalyzeWithTemplate prints `tx` through `tmpl`, which gets executed
+// against its Result.
+func (a *Analyzer) AnalyzeWithTemplate(tx *eos.Transaction, tmpl *template.Template) error {
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, transactionResult(tx)); err != nil {
+		return fmt.Errorf("executing template, %s", err)
+	}
+
+	a.write(buf.String())
+	return nil
+}
+
+// DecodeActions returns the actions of `tx` (excluding the
+// context-free ones), in the same order, as DecodedActions.
+func (a *Analyzer) DecodeActions(tx *eos.Transaction) ([]DecodedAction, error) {
+	out := []DecodedAction{}
+	for idx, act := range tx.Actions {
+		if act == nil {
+			return nil, fmt.Errorf("action %d is nil", idx+1)
+		}
+		out = append(out, decodeAction(act))
+	}
+	return out, nil
+}
+
+func decodeAction(act *eos.Action) DecodedAction {
+	decoded := DecodedAction{
+		Account:        act.Account,
+		Name:           act.Name,
+		Authorizations: authorizationStrings(act),
+		Data:           actionData(act),
+	}
+	if decoded.Data == nil {
+		decoded.Data = hex.EncodeToString(act.HexData)
+	}
+	return decoded
+}
+
+func authorizationStrings(act *eos.Action) []string {
+	return permissionLevelStrings(act.Authorization)
+}
@@ -0,0 +1,155 @@
+package analysis
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/eoscanada/eos-go/token"
+	"github.com/vmihailenco/msgpack"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestAnalyzePackedMsgpack(t *testing.T) {
+	raw := []byte{0xde, 0xad, 0xbe, 0xef}
+	trx := testPack(t, testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", "hi"),
+		testRawAction("mycontract", "doit", raw),
+	))
+
+	cnt, err := NewAnalyzer(false).AnalyzePackedMsgpack(trx)
+	if err != nil {
+		t.Fatalf("AnalyzePackedMsgpack: %s", err)
+	}
+	if len(cnt) == 0 {
+		t.Fatal("AnalyzePackedMsgpack returned no bytes")
+	}
+
+	var res Result
+	if err := msgpack.Unmarshal(cnt, &res); err != nil {
+		t.Fatalf("unmarshaling msgpack: %s", err)
+	}
+	if len(res.Actions) != 2 {
+		t.Fatalf("got %d actions, want 2", len(res.Actions))
+	}
+	if res.ID != ID(trx) {
+		t.Errorf("got ID %q, want %q", res.ID, ID(trx))
+	}
+	if data, ok := res.Actions[1].Data.([]byte); !ok || !bytes.Equal(data, raw) {
+		t.Errorf("got raw data %#v, want the bytes %x", res.Actions[1].Data, raw)
+	}
+}
+
+func TestDecodeActions(t *testing.T) {
+	raw := []byte{0xde, 0xad, 0xbe, 0xef}
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", "hi"),
+		testRawAction("mycontract", "doit", raw),
+	)
+
+	decoded, err := NewAnalyzer(false).DecodeActions(tx)
+	if err != nil {
+		t.Fatalf("DecodeActions: %s", err)
+	}
+	if len(decoded) != 2 {
+		t.Fatalf("got %d actions, want 2", len(decoded))
+	}
+
+	transfer, ok := decoded[0].Data.(*token.Transfer)
+	if !ok {
+		t.Fatalf("got data %#v, want a *token.Transfer", decoded[0].Data)
+	}
+	if transfer.From != "alice" || transfer.To != "bob" || transfer.Memo != "hi" {
+		t.Errorf("got transfer %+v", transfer)
+	}
+	if decoded[0].Account != "eosio.token" || decoded[0].Name != "transfer" {
+		t.Errorf("got action %s::%s, want eosio.token::transfer", decoded[0].Account, decoded[0].Name)
+	}
+	if len(decoded[0].Authorizations) != 1 || decoded[0].Authorizations[0] != "alice@active" {
+		t.Errorf("got authorizations %v, want [alice@active]", decoded[0].Authorizations)
+	}
+	if decoded[1].Data != "deadbeef" {
+		t.Errorf("got raw data %#v, want %q", decoded[1].Data, "deadbeef")
+	}
+}
+
+func TestAnalyzePackedYAML(t *testing.T) {
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", "hi"),
+		testRawAction("mycontract", "doit", []byte{0xde, 0xad, 0xbe, 0xef}),
+	)
+	trx := testPack(t, tx)
+
+	cnt, err := NewAnalyzer(false).AnalyzePackedYAML(trx)
+	if err != nil {
+		t.Fatalf("AnalyzePackedYAML: %s", err)
+	}
+
+	var res struct {
+		ID         string `yaml:"id"`
+		Expiration string `yaml:"expiration"`
+		Actions    []struct {
+			Name string      `yaml:"name"`
+			Data interface{} `yaml:"data"`
+		} `yaml:"actions"`
+	}
+	if err := yaml.Unmarshal(cnt, &res); err != nil {
+		t.Fatalf("unmarshaling YAML: %s\n%s", err, cnt)
+	}
+	if res.ID != ID(trx) {
+		t.Errorf("got ID %q, want %q", res.ID, ID(trx))
+	}
+	if want := tx.Expiration.Time.Format(time.RFC3339); res.Expiration != want {
+		t.Errorf("got expiration %q, want %q", res.Expiration, want)
+	}
+	if len(res.Actions) != 2 || res.Actions[0].Name != "transfer" || res.Actions[1].Name != "doit" {
+		t.Fatalf("got actions %+v, want transfer and doit", res.Actions)
+	}
+	if res.Actions[1].Data != "deadbeef" {
+		t.Errorf("got raw data %#v, want %q", res.Actions[1].Data, "deadbeef")
+	}
+}
+
+func TestAnalyzeWithTemplate(t *testing.T) {
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", ""),
+		testTransfer(t, "bob", "carol", "1.0000 EOS", ""),
+	)
+	tmpl := template.Must(template.New("house").Parse("{{.ID}} has {{len .Actions}} actions\n"))
+
+	a := NewAnalyzer(false)
+	if err := a.AnalyzeWithTemplate(tx, tmpl); err != nil {
+		t.Fatalf("AnalyzeWithTemplate: %s", err)
+	}
+	if out, want := a.String(), transactionID(tx)+" has 2 actions\n"; out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+
+	broken := template.Must(template.New("broken").Parse("{{.Missing}}"))
+	if err := NewAnalyzer(false).AnalyzeWithTemplate(tx, broken); err == nil {
+		t.Error("AnalyzeWithTemplate succeeded on a template using an unknown field")
+	}
+}
+
+func TestHeader(t *testing.T) {
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""))
+	tx.RefBlockNum = 4242
+	tx.RefBlockPrefix = 0xdeadbeef
+	tx.MaxNetUsageWords = 1000
+	tx.MaxCPUUsageMS = 30
+	tx.DelaySec = 3600
+
+	got := NewAnalyzer(false).Header(tx)
+	want := TransactionHeader{
+		Expiration:       tx.Expiration.Time,
+		RefBlockNum:      4242,
+		RefBlockPrefix:   0xdeadbeef,
+		MaxNetUsageWords: 1000,
+		MaxCPUUsageMS:    30,
+		DelaySec:         3600,
+	}
+	if got != want {
+		t.Errorf("got header %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,42 @@
+package analysis
+
+import (
+	"fmt"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// VerifyRoundTrip checks that packing the transaction unpacked from
+// `trx` yields the bytes it was unpacked from (decompressed, if
+// needed), pointing at the first differing byte otherwise.
+func (a *Analyzer) VerifyRoundTrip(trx *eos.PackedTransaction) error {
+	trxData, _, err := signedPayloads(trx)
+	if err != nil {
+		return err
+	}
+
+	sTx, err := a.Unpack(trx)
+	if err != nil {
+		return fmt.Errorf("unpacking transaction, %s", err)
+	}
+
+	repacked, err := eos.MarshalBinary(sTx.Transaction)
+	if err != nil {
+		return fmt.Errorf("repacking transaction, %s", err)
+	}
+
+	common := len(trxData)
+	if len(repacked) < common {
+		common = len(repacked)
+	}
+	for idx := 0; idx < common; idx++ {
+		if trxData[idx] != repacked[idx] {
+			return fmt.Errorf("repacked transaction differs at byte %d: %#02x instead of %#02x", idx, repacked[idx], trxData[idx])
+		}
+	}
+	if len(trxData) != len(repacked) {
+		return fmt.Errorf("repacked transaction differs at byte %d: %d bytes long instead of %d", common, len(repacked), len(trxData))
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package analysis
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestVerifyRoundTrip(t *testing.T) {
+	trx := testPack(t, testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "round trip")))
+	if err := NewAnalyzer(false).VerifyRoundTrip(trx); err != nil {
+		t.Errorf("VerifyRoundTrip: %s", err)
+	}
+
+	size := len(trx.PackedTransaction)
+	trx.PackedTransaction = append(trx.PackedTransaction, 0x00)
+	err := NewAnalyzer(false).VerifyRoundTrip(trx)
+	if err == nil {
+		t.Fatal("VerifyRoundTrip succeeded on a transaction with a trailing byte")
+	}
+	if want := "differs at byte " + strconv.Itoa(size); !strings.Contains(err.Error(), want) {
+		t.Errorf("got error %q, want it to contain %q", err, want)
+	}
+}
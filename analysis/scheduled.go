@@ -0,0 +1,29 @@
+package analysis
+
+import (
+	"fmt"
+	"time"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// AnalyzeScheduled analyzes a deferred transaction, as returned by
+// the `get_scheduled_transactions` endpoint: `packedTrx` is the
+// binary-packed Transaction, `delayUntil` the time from which it
+// executes and `published` the time it got scheduled at.
+func (a *Analyzer) AnalyzeScheduled(packedTrx []byte, delayUntil time.Time, published time.Time) error {
+	var tx eos.Transaction
+	if err := eos.UnmarshalBinary(packedTrx, &tx); err != nil {
+		return fmt.Errorf("unpacking scheduled transaction, %s", err)
+	}
+
+	a.section("SCHEDULED TRANSACTION")
+	a.Pf("Published: %s\n", a.formatTime(published))
+	a.Pf("Delay until: %s (%s after publication)\n", a.formatTime(delayUntil), delayUntil.Sub(published))
+	a.Pf("Execution window: from %s to %s\n", a.formatTime(delayUntil), a.formatTime(tx.Expiration.Time))
+	if !tx.Expiration.Time.After(delayUntil) {
+		a.warn("expires before it can execute")
+	}
+
+	return a.AnalyzeTransaction(&tx)
+}
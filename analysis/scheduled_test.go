@@ -0,0 +1,47 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+func testScheduled(t *testing.T, expiration time.Time) []byte {
+	t.Helper()
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "deferred"))
+	tx.Expiration = eos.JSONTime{Time: expiration}
+	data, err := eos.MarshalBinary(tx)
+	if err != nil {
+		t.Fatalf("packing transaction: %s", err)
+	}
+	return data
+}
+
+func TestAnalyzeScheduled(t *testing.T) {
+	published := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	delayUntil := published.Add(time.Hour)
+
+	a := NewAnalyzer(false)
+	a.TimeFormat = time.RFC3339
+	if err := a.AnalyzeScheduled(testScheduled(t, delayUntil.Add(10*time.Minute)), delayUntil, published); err != nil {
+		t.Fatalf("AnalyzeScheduled: %s", err)
+	}
+	out := a.String()
+	assertContains(t, out, "Published: 2018-06-01T12:00:00Z\n")
+	assertContains(t, out, "Delay until: 2018-06-01T13:00:00Z (1h0m0s after publication)\n")
+	assertContains(t, out, "Execution window: from 2018-06-01T13:00:00Z to 2018-06-01T13:10:00Z\n")
+	assertContains(t, out, `memo: "deferred"`)
+	assertNotContains(t, out, "expires before it can execute")
+}
+
+func TestAnalyzeScheduledUnexecutable(t *testing.T) {
+	published := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	delayUntil := published.Add(time.Hour)
+
+	a := NewAnalyzer(false)
+	if err := a.AnalyzeScheduled(testScheduled(t, published.Add(time.Minute)), delayUntil, published); err != nil {
+		t.Fatalf("AnalyzeScheduled: %s", err)
+	}
+	assertContains(t, a.String(), "WARNING: expires before it can execute\n")
+}
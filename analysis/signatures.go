@@ -0,0 +1,114 @@
+package analysis
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+)
+
+// AnalyzeSignatures recovers the public key behind each signature of
+// `trx`, as signed for `chainID`. Signatures that can't be recovered
+// are reported inline.
+func (a *Analyzer) AnalyzeSignatures(trx *eos.PackedTransaction, chainID eos.SHA256Bytes) error {
+	if !a.shows(SectionSignatures) {
+		return nil
+	}
+
+	a.section("SIGNATURES")
+
+	trxData, cfdData, err := signedPayloads(trx)
+	if err != nil {
+		return err
+	}
+
+	digest := eos.SigDigest(chainID, trxData, cfdData)
+	a.Pf("Chain ID: %s\n", hex.EncodeToString(chainID))
+	a.Pf("Signing digest: %s\n", hex.EncodeToString(digest))
+	a.Pf("Signatures: %d\n", len(trx.Signatures))
+	if a.SignatureColumns {
+		a.signatureColumns(trx.Signatures, digest)
+		return nil
+	}
+	for idx, sig := range trx.Signatures {
+		pubKey, err := sig.PublicKey(digest)
+		if err != nil {
+			a.Pf("%d. %s, couldn't recover public key: %s\n", idx+1, sig, err)
+			continue
+		}
+		a.Pf("%d. %s, signed by: %s\n", idx+1, sig, pubKey)
+	}
+
+	return nil
+}
+
+// signatureColumns prints each of `sigs` next to the public key it
+// recovers to from `digest`, marking those that don't recover.
+func (a *Analyzer) signatureColumns(sigs []ecc.Signature, digest []byte) {
+	width := len("SIGNATURE")
+	for _, sig := range sigs {
+		if l := len(sig.String()); l > width {
+			width = l
+		}
+	}
+
+	a.Pf("   %-*s  %s\n", width, "SIGNATURE", "RECOVERED KEY")
+	for idx, sig := range sigs {
+		pubKey, err := sig.PublicKey(digest)
+		if err != nil {
+			a.Pf("%d. %-*s  FAILED: %s\n", idx+1, width, sig, err)
+			continue
+		}
+		a.Pf("%d. %-*s  %s\n", idx+1, width, sig, pubKey)
+	}
+}
+
+// AnalyzeDigest prints the digest that gets signed for `tx` on the
+// chain identified by `chainID`: the sha256 of the chain ID, the
+// packed transaction and the hash of the packed context-free data
+// (of which a bare Transaction has none).
+func (a *Analyzer) AnalyzeDigest(tx *eos.Transaction, chainID eos.SHA256Bytes) {
+	trxData, err := eos.MarshalBinary(tx)
+	if err != nil {
+		a.Pf("Couldn't pack transaction: %s\n", err)
+		return
+	}
+
+	a.Pf("Chain ID: %s\n", hex.EncodeToString(chainID))
+	a.Pf("Signing digest: %s\n", hex.EncodeToString(eos.SigDigest(chainID, trxData, nil)))
+}
+
+// signedPayloads returns the uncompressed packed transaction and
+// context-free data, which is what the signatures cover.
+func signedPayloads(trx *eos.PackedTransaction) (trxData, cfdData []byte, err error) {
+	if trx.Compression != eos.CompressionZlib {
+		return trx.PackedTransaction, trx.PackedContextFreeData, nil
+	}
+
+	if trxData, err = inflate(trx.PackedTransaction); err != nil {
+		return nil, nil, fmt.Errorf("decompressing packed transaction, %s", err)
+	}
+	if cfdData, err = inflate(trx.PackedContextFreeData); err != nil {
+		return nil, nil, fmt.Errorf("decompressing packed context free data, %s", err)
+	}
+
+	return trxData, cfdData, nil
+}
+
+func inflate(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	reader, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
@@ -0,0 +1,51 @@
+package analysis
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/ecc"
+)
+
+// testChainID is the chain ID of the EOS mainnet.
+const testChainID = "aca376f206b8fc25a6ed44dbdc66547c36c6c33e3a119ffbeaef943642f0e906"
+
+func TestAnalyzeDigest(t *testing.T) {
+	chainID, _ := hex.DecodeString(testChainID)
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "hi"))
+	tx.Expiration = eos.JSONTime{Time: time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)}
+	tx.RefBlockNum, tx.RefBlockPrefix = 1234, 5678
+
+	a := NewAnalyzer(false)
+	a.AnalyzeDigest(tx, chainID)
+	out := a.String()
+	assertContains(t, out, "Chain ID: "+testChainID+"\n")
+	assertContains(t, out, "Signing digest: a1d92bdb10d2172d63fa28856fbba6f14be09982986f7503b74a68d474b2aa26\n")
+}
+
+func TestAnalyzeSignatureColumns(t *testing.T) {
+	chainID, _ := hex.DecodeString(testChainID)
+	trx := testPack(t, testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "")))
+
+	key := testKey(t)
+	sig, err := key.Sign(eos.SigDigest(chainID, trx.PackedTransaction, trx.PackedContextFreeData))
+	if err != nil {
+		t.Fatalf("signing: %s", err)
+	}
+	corrupt := ecc.Signature{Curve: ecc.CurveK1, Content: make([]byte, 65)}
+	trx.Signatures = []ecc.Signature{sig, corrupt}
+
+	a := NewAnalyzer(false)
+	a.SignatureColumns = true
+	if err := a.AnalyzeSignatures(trx, chainID); err != nil {
+		t.Fatalf("AnalyzeSignatures: %s", err)
+	}
+	out := a.String()
+	width := len(sig.String())
+	assertContains(t, out, fmt.Sprintf("   %-*s  RECOVERED KEY\n", width, "SIGNATURE"))
+	assertContains(t, out, fmt.Sprintf("1. %s  %s\n", sig, key.PublicKey()))
+	assertContains(t, out, fmt.Sprintf("2. %-*s  FAILED: ", width, corrupt))
+}
@@ -0,0 +1,45 @@
+package analysis
+
+import (
+	eos "github.com/eoscanada/eos-go"
+)
+
+// AnalyzeSize prints the serialized size of `tx`, and how many bytes
+// the header and each action contribute to it.
+func (a *Analyzer) AnalyzeSize(tx *eos.Transaction) {
+	a.section("TRANSACTION SIZE")
+
+	total, err := eos.MarshalBinary(tx)
+	if err != nil {
+		a.Pf("Couldn't serialize transaction: %s\n", err)
+		return
+	}
+	a.Pf("Total serialized size: %d bytes\n", len(total))
+
+	header, err := eos.MarshalBinary(tx.TransactionHeader)
+	if err != nil {
+		a.Pf("Couldn't serialize transaction header: %s\n", err)
+		return
+	}
+	a.Pf("Header: %d bytes\n", len(header))
+
+	accounted := len(header)
+	accounted += a.analyzeActionsSize("Context-free action", tx.ContextFreeActions)
+	accounted += a.analyzeActionsSize("Action", tx.Actions)
+
+	// List lengths and transaction extensions make up the rest.
+	a.Pf("Length prefixes and extensions: %d bytes\n", len(total)-accounted)
+}
+
+func (a *Analyzer) analyzeActionsSize(label string, actions []*eos.Action) (total int) {
+	for idx, act := range actions {
+		data, err := eos.MarshalBinary(act)
+		if err != nil {
+			a.Pf("%s %d (%s::%s): couldn't serialize: %s\n", label, idx+1, act.Account, act.Name, err)
+			continue
+		}
+		a.Pf("%s %d (%s::%s): %d bytes\n", label, idx+1, act.Account, act.Name, len(data))
+		total += len(data)
+	}
+	return
+}
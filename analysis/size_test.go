@@ -0,0 +1,32 @@
+package analysis
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestAnalyzeSize(t *testing.T) {
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", "first"),
+		testTransfer(t, "bob", "carol", "2.0000 EOS", "a somewhat longer memo"),
+	)
+
+	a := NewAnalyzer(false)
+	a.AnalyzeSize(tx)
+	out := a.String()
+
+	sizes := map[string]int{}
+	for _, match := range regexp.MustCompile(`(?m)^(Total serialized size|Header|Action \d+)\b.*?(\d+) bytes$`).FindAllStringSubmatch(out, -1) {
+		size, _ := strconv.Atoi(match[2])
+		sizes[match[1]] = size
+	}
+	if len(sizes) != 4 {
+		t.Fatalf("got sizes %v, want the total, header and 2 actions:\n%s", sizes, out)
+	}
+
+	parts := sizes["Header"] + sizes["Action 1"] + sizes["Action 2"]
+	if total := sizes["Total serialized size"]; parts > total || total-parts > 8 {
+		t.Errorf("header and actions add up to %d bytes, for a total of %d", parts, total)
+	}
+}
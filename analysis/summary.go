@@ -0,0 +1,38 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// actionCensus returns a one-line fingerprint of `tx`, counting its
+// actions grouped by `account::name`, most frequent first.
+func actionCensus(tx *eos.Transaction) string {
+	counts := map[string]int{}
+	var names []string
+	all := append(append([]*eos.Action{}, tx.ContextFreeActions...), tx.Actions...)
+	for _, act := range all {
+		name := fmt.Sprintf("%s::%s", act.Account, act.Name)
+		if counts[name] == 0 {
+			names = append(names, name)
+		}
+		counts[name]++
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	var entries []string
+	for _, name := range names {
+		entries = append(entries, fmt.Sprintf("%s x%d", name, counts[name]))
+	}
+
+	return fmt.Sprintf("%d actions (%s)", len(all), strings.Join(entries, ", "))
+}
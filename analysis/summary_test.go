@@ -0,0 +1,23 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/eoscanada/eos-go/system"
+)
+
+func TestAnalyzeSummary(t *testing.T) {
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", ""),
+		system.NewDelegateBW("alice", "bob", testAsset(t, "1.0000 EOS"), testAsset(t, "1.0000 EOS"), false),
+		testTransfer(t, "bob", "carol", "2.0000 EOS", ""),
+	)
+
+	a := NewAnalyzer(false)
+	a.Summary = true
+	out := testAnalyze(t, a, tx)
+	assertContains(t, out, "Summary: 3 actions (eosio.token::transfer x2, eosio::delegatebw x1)\n")
+
+	out = testAnalyze(t, NewAnalyzer(false), tx)
+	assertNotContains(t, out, "Summary:")
+}
@@ -0,0 +1,36 @@
+package analysis
+
+import (
+	eos "github.com/eoscanada/eos-go"
+)
+
+const (
+	// taposWindow is how many of the latest blocks a transaction can
+	// reference, as only the lower 16 bits of the block number are
+	// kept in `RefBlockNum`.
+	taposWindow = 1 << 16
+
+	// taposStaleBlocks is how close to leaving the TAPoS window a
+	// reference block must be for a warning to be printed: about an
+	// hour, at two blocks per second.
+	taposStaleBlocks = 7200
+)
+
+// CheckRefBlock compares the reference block of `tx` with the chain
+// head at `headBlockNum`, warning when it's stale or ahead of the
+// head, and printing for how many blocks it likely remains valid.
+func (a *Analyzer) CheckRefBlock(tx *eos.Transaction, headBlockNum uint32) {
+	a.Pf("Head block number: %d\n", headBlockNum)
+
+	if headBlockNum < taposWindow && uint32(tx.RefBlockNum) > headBlockNum {
+		a.warn("reference block %d is ahead of head block %d", tx.RefBlockNum, headBlockNum)
+		return
+	}
+
+	behind := uint32(uint16(headBlockNum) - tx.RefBlockNum)
+	remaining := taposWindow - 1 - behind
+	a.Pf("Reference block: %d blocks behind head, valid for about %d more blocks\n", behind, remaining)
+	if remaining < taposStaleBlocks {
+		a.warn("stale reference block, %d blocks behind head", behind)
+	}
+}
@@ -0,0 +1,34 @@
+package analysis
+
+import "testing"
+
+func TestCheckRefBlockStale(t *testing.T) {
+	tx := testTransaction()
+	tx.RefBlockNum = 1000
+
+	a := NewAnalyzer(false)
+	a.CheckRefBlock(tx, 1000+60000)
+	out := a.String()
+	assertContains(t, out, "Reference block: 60000 blocks behind head, valid for about 5535 more blocks\n")
+	assertContains(t, out, "WARNING: stale reference block, 60000 blocks behind head\n")
+}
+
+func TestCheckRefBlockFresh(t *testing.T) {
+	tx := testTransaction()
+	tx.RefBlockNum = uint16((70000 - 10) % taposWindow) // the lower 16 bits only
+
+	a := NewAnalyzer(false)
+	a.CheckRefBlock(tx, 70000)
+	out := a.String()
+	assertContains(t, out, "Reference block: 10 blocks behind head, valid for about 65525 more blocks\n")
+	assertNotContains(t, out, "WARNING")
+}
+
+func TestCheckRefBlockAhead(t *testing.T) {
+	tx := testTransaction()
+	tx.RefBlockNum = 2000
+
+	a := NewAnalyzer(false)
+	a.CheckRefBlock(tx, 1000)
+	assertContains(t, a.String(), "WARNING: reference block 2000 is ahead of head block 1000\n")
+}
@@ -0,0 +1,43 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	eos "github.com/eoscanada/eos-go"
+)
+
+// blockInterval is the time between two blocks.
+const blockInterval = 500 * time.Millisecond
+
+// AnalyzeTimeline prints, in chronological order, when `tx` can
+// execute at the earliest (given its delay), when its reference block
+// leaves the TAPoS window and when it expires.
+//
+// The reference block is assumed to be recent, as we don't know the
+// time it was produced at.
+func (a *Analyzer) AnalyzeTimeline(tx *eos.Transaction) {
+	now := time.Now().UTC()
+	earliest := now.Add(time.Duration(tx.DelaySec) * time.Second)
+
+	type event struct {
+		at    time.Time
+		label string
+	}
+	events := []event{
+		{now, "now"},
+		{earliest, "earliest execution (now + delay)"},
+		{now.Add(taposWindow * blockInterval), "end of reference block validity (approximate)"},
+		{tx.Expiration.Time, "expiration"},
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+
+	a.section("TIMELINE")
+	for _, ev := range events {
+		a.Pf("%s  %s\n", a.formatTime(ev.at), ev.label)
+	}
+
+	if earliest.After(tx.Expiration.Time) {
+		a.warn("delay of %ds pushes execution past expiration, the transaction can't execute", tx.DelaySec)
+	}
+}
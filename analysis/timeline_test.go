@@ -0,0 +1,38 @@
+package analysis
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// timelineLabel matches the label of a timeline line, after its
+// RFC3339 time.
+var timelineLabel = regexp.MustCompile(`(?m)^\S+  (.+)$`)
+
+func TestAnalyzeTimelineDelayPastExpiration(t *testing.T) {
+	tx := testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", ""))
+	tx.DelaySec = 7200
+
+	a := NewAnalyzer(false)
+	a.TimeFormat = time.RFC3339
+	a.AnalyzeTimeline(tx)
+	out := a.String()
+	assertContains(t, out, "WARNING: delay of 7200s pushes execution past expiration, the transaction can't execute\n")
+
+	var labels []string
+	for _, match := range timelineLabel.FindAllStringSubmatch(out, -1) {
+		labels = append(labels, match[1])
+	}
+	want := []string{"now", "expiration", "earliest execution (now + delay)", "end of reference block validity (approximate)"}
+	if strings.Join(labels, "|") != strings.Join(want, "|") {
+		t.Errorf("got events %q, want %q", labels, want)
+	}
+}
+
+func TestAnalyzeTimeline(t *testing.T) {
+	a := NewAnalyzer(false)
+	a.AnalyzeTimeline(testTransaction(testTransfer(t, "alice", "bob", "1.0000 EOS", "")))
+	assertNotContains(t, a.String(), "WARNING")
+}
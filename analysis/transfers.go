@@ -0,0 +1,41 @@
+package analysis
+
+import (
+	eos "github.com/eoscanada/eos-go"
+	"github.com/eoscanada/eos-go/token"
+)
+
+// transferKey groups transfers by sender and token. The contract is
+// part of the token's identity: anyone can deploy a token with the
+// `EOS` symbol.
+type transferKey struct {
+	from     eos.AccountName
+	contract eos.AccountName
+	symbol   eos.Symbol
+}
+
+// AnalyzeTransferTotals prints the sum of the quantities transferred
+// by `tx`, for each sender and token, in order of first appearance.
+func (a *Analyzer) AnalyzeTransferTotals(tx *eos.Transaction) {
+	totals := map[transferKey]eos.Asset{}
+	var keys []transferKey
+	for _, act := range tx.Actions {
+		transfer, ok := actionData(act).(*token.Transfer)
+		if !ok {
+			continue
+		}
+
+		key := transferKey{from: transfer.From, contract: act.Account, symbol: transfer.Quantity.Symbol}
+		total, seen := totals[key]
+		if !seen {
+			keys = append(keys, key)
+			total = eos.Asset{Symbol: key.symbol}
+		}
+		totals[key] = total.Add(transfer.Quantity)
+	}
+
+	a.Pf("Transfer totals: %d\n", len(keys))
+	for _, key := range keys {
+		a.Pf("- %s sends %s (on %s)\n", key.from, totals[key], key.contract)
+	}
+}
@@ -0,0 +1,24 @@
+package analysis
+
+import "testing"
+
+func TestAnalyzeTransferTotals(t *testing.T) {
+	other := testTransfer(t, "alice", "carol", "10.000 PEOS", "")
+	other.Account = "pegtoken"
+	tx := testTransaction(
+		testTransfer(t, "alice", "bob", "1.0000 EOS", ""),
+		other,
+		testTransfer(t, "bob", "carol", "3.0000 EOS", ""),
+		testTransfer(t, "alice", "carol", "2.5000 EOS", ""),
+	)
+
+	a := NewAnalyzer(false)
+	a.AnalyzeTransferTotals(tx)
+	want := "Transfer totals: 3\n" +
+		"- alice sends 3.5000 EOS (on eosio.token)\n" +
+		"- alice sends 10.000 PEOS (on pegtoken)\n" +
+		"- bob sends 3.0000 EOS (on eosio.token)\n"
+	if out := a.String(); out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
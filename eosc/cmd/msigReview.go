@@ -15,7 +15,6 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	eos "github.com/eoscanada/eos-go"
 	"github.com/eoscanada/eosc/analysis"
@@ -65,7 +64,7 @@ var msigReviewCmd = &cobra.Command{
 				fmt.Println("Proposer:", proposer)
 				fmt.Println("Proposal name:", proposalName)
 				fmt.Println()
-				os.Stdout.Write(ana.Writer.Bytes())
+				fmt.Print(ana.String())
 			}
 		}
 		if tx == nil {